@@ -0,0 +1,322 @@
+package beacon
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+
+	"github.com/drand/drand/key"
+)
+
+// archive contains a portable format to export a range of the chain and import
+// it into another store. An archive is a stream laid out as follows:
+//
+//    magic (8) | version (1) | len(groupHash) (1) | groupHash | from (8) | to (8)
+//
+// followed by the beacons in increasing round order, each one being
+//
+//    len(record) (4) | record
+//
+// where record uses the binary record format of encoding.go, linking to the
+// previous beacon of the archive when possible. The stream ends with a record
+// of length zero followed by the sha256 checksum of all the preceding bytes.
+
+// archiveMagic is written at the start of every archive.
+var archiveMagic = []byte("drandarc")
+
+// archiveV1 is the version of the archive format.
+const archiveV1 byte = 0x01
+
+// ErrArchiveChecksum is returned when the checksum of an archive does not match
+// its content.
+var ErrArchiveChecksum = errors.New("archive: invalid checksum")
+
+// ArchiveHeader describes the content of an archive.
+type ArchiveHeader struct {
+	// GroupHash is the hash of the group that produced the chain.
+	GroupHash []byte
+	// From and To are the first and last rounds of the archive.
+	From uint64
+	To   uint64
+}
+
+// ArchiveWriter writes beacons to an archive.
+type ArchiveWriter struct {
+	w      *bufio.Writer
+	h      hash.Hash
+	header *ArchiveHeader
+	last   *Beacon
+}
+
+// NewArchiveWriter writes the header of the archive to w and returns a writer
+// ready to write beacons. Close must be called once all beacons are written.
+func NewArchiveWriter(w io.Writer, header *ArchiveHeader) (*ArchiveWriter, error) {
+	if len(header.GroupHash) > 255 {
+		return nil, errors.New("archive: group hash too long")
+	}
+	if header.From > header.To {
+		return nil, errors.New("archive: invalid round range")
+	}
+	a := &ArchiveWriter{
+		w:      bufio.NewWriter(w),
+		h:      sha256.New(),
+		header: header,
+	}
+	var buff bytes.Buffer
+	buff.Write(archiveMagic)
+	buff.WriteByte(archiveV1)
+	buff.WriteByte(byte(len(header.GroupHash)))
+	buff.Write(header.GroupHash)
+	binary.Write(&buff, binary.BigEndian, header.From)
+	binary.Write(&buff, binary.BigEndian, header.To)
+	return a, a.write(buff.Bytes())
+}
+
+func (a *ArchiveWriter) write(p []byte) error {
+	a.h.Write(p)
+	_, err := a.w.Write(p)
+	return err
+}
+
+// Write appends the beacon to the archive. Beacons must be written in
+// increasing round order and be in the range of the header.
+func (a *ArchiveWriter) Write(b *Beacon) error {
+	if b.Round < a.header.From || b.Round > a.header.To {
+		return fmt.Errorf("archive: round %d out of range", b.Round)
+	}
+	var prevSig []byte
+	if a.last != nil {
+		if b.Round <= a.last.Round {
+			return fmt.Errorf("archive: round %d written after round %d", b.Round, a.last.Round)
+		}
+		if b.Round == a.last.Round+1 {
+			prevSig = a.last.Signature
+		}
+	}
+	record := encodeRecord(b, prevSig)
+	var length [4]byte
+	binary.BigEndian.PutUint32(length[:], uint32(len(record)))
+	if err := a.write(length[:]); err != nil {
+		return err
+	}
+	a.last = b
+	return a.write(record)
+}
+
+// Close writes the end of the archive and its checksum. It does not close the
+// underlying writer.
+func (a *ArchiveWriter) Close() error {
+	if err := a.write(make([]byte, 4)); err != nil {
+		return err
+	}
+	if _, err := a.w.Write(a.h.Sum(nil)); err != nil {
+		return err
+	}
+	return a.w.Flush()
+}
+
+// ArchiveReader reads beacons from an archive.
+type ArchiveReader struct {
+	r      *bufio.Reader
+	h      hash.Hash
+	header *ArchiveHeader
+	last   *Beacon
+	done   bool
+}
+
+// NewArchiveReader reads the header of the archive from r and returns a reader
+// ready to read the beacons.
+func NewArchiveReader(r io.Reader) (*ArchiveReader, error) {
+	a := &ArchiveReader{
+		r: bufio.NewReader(r),
+		h: sha256.New(),
+	}
+	fixed := make([]byte, len(archiveMagic)+2)
+	if err := a.read(fixed); err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(fixed[:len(archiveMagic)], archiveMagic) {
+		return nil, errors.New("archive: invalid magic")
+	}
+	if v := fixed[len(archiveMagic)]; v != archiveV1 {
+		return nil, fmt.Errorf("archive: unknown version %d", v)
+	}
+	rest := make([]byte, int(fixed[len(archiveMagic)+1])+16)
+	if err := a.read(rest); err != nil {
+		return nil, err
+	}
+	hashLen := len(rest) - 16
+	a.header = &ArchiveHeader{
+		GroupHash: rest[:hashLen],
+		From:      binary.BigEndian.Uint64(rest[hashLen : hashLen+8]),
+		To:        binary.BigEndian.Uint64(rest[hashLen+8:]),
+	}
+	return a, nil
+}
+
+func (a *ArchiveReader) read(p []byte) error {
+	if _, err := io.ReadFull(a.r, p); err != nil {
+		if err == io.EOF {
+			return io.ErrUnexpectedEOF
+		}
+		return err
+	}
+	a.h.Write(p)
+	return nil
+}
+
+// Header returns the header of the archive.
+func (a *ArchiveReader) Header() *ArchiveHeader {
+	return a.header
+}
+
+// Next returns the next beacon of the archive. It returns io.EOF once all
+// beacons have been read and the checksum of the archive has been verified.
+// Note that the beacons are NOT verified.
+func (a *ArchiveReader) Next() (*Beacon, error) {
+	if a.done {
+		return nil, io.EOF
+	}
+	var length [4]byte
+	if err := a.read(length[:]); err != nil {
+		return nil, err
+	}
+	size := binary.BigEndian.Uint32(length[:])
+	if size == 0 {
+		expected := a.h.Sum(nil)
+		sum := make([]byte, len(expected))
+		if _, err := io.ReadFull(a.r, sum); err != nil {
+			return nil, io.ErrUnexpectedEOF
+		}
+		if !bytes.Equal(sum, expected) {
+			return nil, ErrArchiveChecksum
+		}
+		a.done = true
+		return nil, io.EOF
+	}
+	record := make([]byte, size)
+	if err := a.read(record); err != nil {
+		return nil, err
+	}
+	b, err := decodeRecord(record, func(round uint64) ([]byte, error) {
+		if a.last == nil || a.last.Round != round {
+			return nil, fmt.Errorf("archive: no beacon at round %d", round)
+		}
+		return a.last.Signature, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if b.Round < a.header.From || b.Round > a.header.To {
+		return nil, fmt.Errorf("archive: round %d out of range", b.Round)
+	}
+	if a.last != nil && b.Round <= a.last.Round {
+		return nil, fmt.Errorf("archive: round %d read after round %d", b.Round, a.last.Round)
+	}
+	a.last = b
+	return b, nil
+}
+
+// ExportChain writes the beacons of the store from round from to round to
+// included into an archive. If to is zero, it exports up to the last beacon of
+// the store. It returns the number of beacons written.
+func ExportChain(w io.Writer, s Store, groupHash []byte, from, to uint64) (int, error) {
+	if to == 0 {
+		last, err := s.Last()
+		if err != nil {
+			return 0, err
+		}
+		to = last.Round
+	}
+	aw, err := NewArchiveWriter(w, &ArchiveHeader{
+		GroupHash: groupHash,
+		From:      from,
+		To:        to,
+	})
+	if err != nil {
+		return 0, err
+	}
+	var n int
+	s.Cursor(func(c Cursor) {
+		for b := c.Seek(from); b != nil && b.Round <= to; b = c.Next() {
+			if err = aw.Write(b); err != nil {
+				return
+			}
+			n++
+		}
+	})
+	if err != nil {
+		return n, err
+	}
+	return n, aw.Close()
+}
+
+// ImportChain verifies and saves into the store all the beacons of the
+// archive. The archive must have been produced by one of the given groups,
+// which must include all groups responsible for the rounds of the archive.
+// Each beacon must build on the previous one, or on the beacon stored at the
+// previous round if it is the first of the archive. The beacons are kept in
+// memory until the checksum of the archive is verified, so nothing is saved
+// if the archive is invalid, truncated or tampered with. It returns the number
+// of beacons saved.
+func ImportChain(r io.Reader, s Store, groups ...*key.Group) (int, error) {
+	ar, err := NewArchiveReader(r)
+	if err != nil {
+		return 0, err
+	}
+	var found bool
+	safe := newCryptoSafe()
+	for _, g := range groups {
+		safe.SetInfo(nil, nil, g)
+		found = found || bytes.Equal(g.Hash(), ar.Header().GroupHash)
+	}
+	if !found {
+		return 0, fmt.Errorf("archive: produced by unknown group %x", ar.Header().GroupHash)
+	}
+	var verified []*Beacon
+	var prev *Beacon
+	for {
+		b, err := ar.Next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return 0, err
+		}
+		if prev == nil && b.Round > 0 {
+			// the first beacon must build on what we have, if anything
+			prev, _ = s.Get(b.Round - 1)
+		}
+		if err := verifyImported(safe, prev, b); err != nil {
+			return 0, err
+		}
+		verified = append(verified, b)
+		prev = b
+	}
+	for i, b := range verified {
+		if err := s.Put(b); err != nil {
+			return i, err
+		}
+	}
+	return len(verified), nil
+}
+
+// verifyImported verifies the beacon against the group responsible for its
+// round and checks it builds on the previous one if not nil.
+func verifyImported(safe *cryptoSafe, prev, b *Beacon) error {
+	if prev != nil && !isAppendable(prev, b) {
+		return fmt.Errorf("archive: round %d does not build on round %d", b.Round, prev.Round)
+	}
+	info, err := safe.GetInfo(b.Round)
+	if err != nil {
+		return err
+	}
+	if err := verifyRound(info, b); err != nil {
+		return fmt.Errorf("archive: invalid beacon at round %d: %s", b.Round, err)
+	}
+	return nil
+}
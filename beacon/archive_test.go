@@ -0,0 +1,90 @@
+package beacon
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestArchiveExportImport(t *testing.T) {
+	group, chain := testChain(t, 3, 2, 10)
+	src, err := NewMemoryStore(20)
+	require.NoError(t, err)
+	for _, b := range chain {
+		require.NoError(t, src.Put(b))
+	}
+
+	var buff bytes.Buffer
+	n, err := ExportChain(&buff, src, group.Hash(), 0, 0)
+	require.NoError(t, err)
+	require.Equal(t, len(chain), n)
+	archive := append([]byte{}, buff.Bytes()...)
+
+	ar, err := NewArchiveReader(bytes.NewReader(archive))
+	require.NoError(t, err)
+	require.Equal(t, group.Hash(), ar.Header().GroupHash)
+	require.Equal(t, uint64(0), ar.Header().From)
+	require.Equal(t, uint64(9), ar.Header().To)
+	for _, expected := range chain {
+		b, err := ar.Next()
+		require.NoError(t, err)
+		require.True(t, expected.Equal(b))
+	}
+	_, err = ar.Next()
+	require.Equal(t, io.EOF, err)
+
+	dst, err := NewMemoryStore(20)
+	require.NoError(t, err)
+	n, err = ImportChain(bytes.NewReader(archive), dst, group)
+	require.NoError(t, err)
+	require.Equal(t, len(chain), n)
+	report, err := VerifyChain(dst, group)
+	require.NoError(t, err)
+	require.True(t, report.Valid())
+	require.Equal(t, uint64(9), report.LastValid)
+
+	// unknown group
+	other, _ := testChain(t, 3, 2, 1)
+	_, err = ImportChain(bytes.NewReader(archive), dst, other)
+	require.Error(t, err)
+
+	// partial export must build on the existing chain
+	buff.Reset()
+	n, err = ExportChain(&buff, src, group.Hash(), 5, 7)
+	require.NoError(t, err)
+	require.Equal(t, 3, n)
+	partial := buff.Bytes()
+	dst, err = NewMemoryStore(20)
+	require.NoError(t, err)
+	require.NoError(t, dst.Put(&Beacon{Round: 4, Signature: []byte("not the right one")}))
+	_, err = ImportChain(bytes.NewReader(partial), dst, group)
+	require.Error(t, err)
+	require.NoError(t, dst.Put(chain[4]))
+	n, err = ImportChain(bytes.NewReader(partial), dst, group)
+	require.NoError(t, err)
+	require.Equal(t, 3, n)
+
+	// corrupted archive
+	corrupted := append([]byte{}, archive...)
+	corrupted[len(corrupted)-40] ^= 0xff
+	_, err = ImportChain(bytes.NewReader(corrupted), dst, group)
+	require.Error(t, err)
+	_, err = ImportChain(bytes.NewReader(archive[:len(archive)-10]), dst, group)
+	require.Error(t, err)
+
+	// nothing is imported from an archive whose beacons are valid but whose
+	// checksum is not
+	tampered := append([]byte{}, archive...)
+	tampered[len(tampered)-1] ^= 0xff
+	dst, err = NewMemoryStore(20)
+	require.NoError(t, err)
+	n, err = ImportChain(bytes.NewReader(tampered), dst, group)
+	require.Equal(t, ErrArchiveChecksum, err)
+	require.Equal(t, 0, n)
+	require.Equal(t, 0, dst.Len())
+	_, err = ImportChain(bytes.NewReader(archive[:len(archive)-10]), dst, group)
+	require.Error(t, err)
+	require.Equal(t, 0, dst.Len())
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"crypto/sha256"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"math"
 	"time"
@@ -20,12 +21,17 @@ type Beacon struct {
 	Round uint64
 	// Signature is the BLS deterministic signature over Round || PreviousRand
 	Signature []byte
+	// UnchainedSig is the BLS signature over the hash of Round alone, so it
+	// can be derived from the round in advance, e.g. to encrypt to it. It is
+	// empty for beacons produced before it was introduced.
+	UnchainedSig []byte `json:",omitempty"`
 }
 
 func (b *Beacon) Equal(b2 *Beacon) bool {
 	return bytes.Equal(b.PreviousSig, b2.PreviousSig) &&
 		b.Round == b2.Round &&
-		bytes.Equal(b.Signature, b2.Signature)
+		bytes.Equal(b.Signature, b2.Signature) &&
+		bytes.Equal(b.UnchainedSig, b2.UnchainedSig)
 
 }
 
@@ -59,12 +65,22 @@ func (b *Beacon) String() string {
 // VerifyBeacon returns an error if the given beacon does not verify given the
 // public key. The public key "point" can be obtained from the
 // `key.DistPublic.Key()` method. The distributed public is the one written in
-// the configuration file of the network.
+// the configuration file of the network. The unchained signature is verified
+// too if the beacon carries one.
 func VerifyBeacon(pubkey kyber.Point, b *Beacon) error {
 	prevSig := b.PreviousSig
 	round := b.Round
 	msg := Message(round, prevSig)
-	return key.Scheme.VerifyRecovered(pubkey, msg, b.Signature)
+	if err := key.Scheme.VerifyRecovered(pubkey, msg, b.Signature); err != nil {
+		return err
+	}
+	if len(b.UnchainedSig) == 0 {
+		return nil
+	}
+	if err := key.Scheme.VerifyRecovered(pubkey, UnchainedMessage(round), b.UnchainedSig); err != nil {
+		return fmt.Errorf("unchained signature: %s", err)
+	}
+	return nil
 }
 
 // Verify is similar to verify beacon but doesn't require to get the full beacon
@@ -87,6 +103,14 @@ func Message(currRound uint64, prevSig []byte) []byte {
 	return h.Sum(nil)
 }
 
+// UnchainedMessage returns the message signed by the unchained signature of a
+// beacon, which only depends on the round.
+// H ( currRound )
+func UnchainedMessage(currRound uint64) []byte {
+	h := sha256.Sum256(roundToBytes(currRound))
+	return h[:]
+}
+
 // TimeOfRound is returning the time the current round should happen
 func TimeOfRound(period time.Duration, genesis int64, round uint64) int64 {
 	if round == 0 {
@@ -104,6 +128,28 @@ func CurrentRound(now int64, period time.Duration, genesis int64) uint64 {
 	return nextRound - 1
 }
 
+// ErrBeforeGenesis is returned when looking up the round of a time before the
+// genesis time of the chain.
+var ErrBeforeGenesis = errors.New("beacon: time is before genesis")
+
+// ErrFutureTime is returned when looking up the round of a time that has not
+// happened yet.
+var ErrFutureTime = errors.New("beacon: time is in the future")
+
+// RoundAtTime returns the round whose randomness was the latest available at
+// the given time, i.e. the round that was current at that time. It returns
+// ErrBeforeGenesis if no randomness was produced at that time and ErrFutureTime
+// if the time is later than now.
+func RoundAtTime(at, now int64, period time.Duration, genesis int64) (uint64, error) {
+	if at < genesis {
+		return 0, ErrBeforeGenesis
+	}
+	if at > now {
+		return 0, ErrFutureTime
+	}
+	return CurrentRound(at, period, genesis), nil
+}
+
 // NextRound returns the next upcoming round and its UNIX time given the genesis
 // time and the period.
 // round at time genesis = round 1. Round 0 is fixed.
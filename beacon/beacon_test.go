@@ -41,3 +41,25 @@ func TestChainNextRound(t *testing.T) {
 	require.Equal(t, expTime2, time2)
 
 }
+
+func TestChainRoundAtTime(t *testing.T) {
+	genesis := int64(1000)
+	period := 2 * time.Second
+	now := genesis + 100
+
+	_, err := RoundAtTime(genesis-1, now, period, genesis)
+	require.Equal(t, ErrBeforeGenesis, err)
+	_, err = RoundAtTime(now+1, now, period, genesis)
+	require.Equal(t, ErrFutureTime, err)
+
+	round, err := RoundAtTime(genesis, now, period, genesis)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), round)
+	round, err = RoundAtTime(genesis+3, now, period, genesis)
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), round)
+	round, err = RoundAtTime(now, now, period, genesis)
+	require.NoError(t, err)
+	require.Equal(t, uint64(51), round)
+	require.Equal(t, now, TimeOfRound(period, genesis, round))
+}
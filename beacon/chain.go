@@ -9,6 +9,8 @@ import (
 	"github.com/drand/drand/log"
 	"github.com/drand/drand/net"
 	"github.com/drand/drand/protobuf/drand"
+	"github.com/drand/kyber/share"
+	"github.com/drand/kyber/sign/tbls"
 )
 
 // chainStore is a Store that deals with reconstructing the beacons, sync when
@@ -153,6 +155,16 @@ func (c *chainStore) runAggregator() {
 				PreviousSig: cache.previousSig,
 				Signature:   finalSig,
 			}
+			// nodes which don't sign the unchained message yet don't prevent
+			// the beacon from being produced
+			if len(cache.unchainedSigs) >= thr {
+				unchainedSig, err := recoverVerified(cache.unchainedSigs, thr, n)
+				if err != nil {
+					c.l.Error("invalid_unchained_sig", err, "round", pRound)
+				} else {
+					newBeacon.UnchainedSig = unchainedSig
+				}
+			}
 			c.l.Info("aggregated_beacon", newBeacon.Round)
 			c.newBeaconCh <- newBeacon
 			break
@@ -268,8 +280,10 @@ type roundCache struct {
 	previous    uint64
 	previousSig []byte
 	sigs        [][]byte
-	seens       map[int]bool
-	done        bool
+	// partial signatures over the unchained message of the round
+	unchainedSigs [][]byte
+	seens         map[int]bool
+	done          bool
 }
 
 func newRoundCache(round uint64, prevSig []byte) *roundCache {
@@ -292,6 +306,9 @@ func (cache *roundCache) tryAppend(p *drand.PartialBeaconPacket) bool {
 	samePrevS := bytes.Equal(prevSig, cache.previousSig)
 	if sameRound && samePrevS {
 		cache.sigs = append(cache.sigs, p.GetPartialSig())
+		if len(p.GetPartialUnchainedSig()) > 0 {
+			cache.unchainedSigs = append(cache.unchainedSigs, p.GetPartialUnchainedSig())
+		}
 		cache.seens[idx] = true
 		return true
 	}
@@ -309,3 +326,26 @@ func (r *roundCache) Msg() []byte {
 func (r *roundCache) Partials() [][]byte {
 	return r.sigs
 }
+
+// recoverVerified recovers the signature from partial signatures which have
+// already been verified when received, without verifying them again.
+func recoverVerified(partials [][]byte, thr, n int) ([]byte, error) {
+	shares := make([]*share.PubShare, 0, len(partials))
+	for _, p := range partials {
+		sh := tbls.SigShare(p)
+		idx, err := sh.Index()
+		if err != nil {
+			return nil, err
+		}
+		point := key.SigGroup.Point()
+		if err := point.UnmarshalBinary(sh.Value()); err != nil {
+			return nil, err
+		}
+		shares = append(shares, &share.PubShare{I: idx, V: point})
+	}
+	sig, err := share.RecoverCommit(key.SigGroup, shares, thr, n)
+	if err != nil {
+		return nil, err
+	}
+	return sig.MarshalBinary()
+}
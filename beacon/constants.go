@@ -9,3 +9,7 @@ var CheckSyncPeriod = 5 * time.Minute
 // Once a connection is made, we should not wait too much to receive new beacons
 // from one peer
 var MaxSyncWaitTime = 2 * time.Second
+
+// CompactionPeriod is the period at which the beacon handler deletes the
+// beacons falling out of its retention policy, if any
+var CompactionPeriod = 10 * time.Minute
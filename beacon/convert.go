@@ -6,16 +6,18 @@ import (
 
 func beaconToProto(b *Beacon) *proto.BeaconPacket {
 	return &proto.BeaconPacket{
-		PreviousSig: b.PreviousSig,
-		Round:       b.Round,
-		Signature:   b.Signature,
+		PreviousSig:  b.PreviousSig,
+		Round:        b.Round,
+		Signature:    b.Signature,
+		UnchainedSig: b.UnchainedSig,
 	}
 }
 
 func protoToBeacon(p *proto.BeaconPacket) *Beacon {
 	return &Beacon{
-		Round:       p.GetRound(),
-		Signature:   p.GetSignature(),
-		PreviousSig: p.GetPreviousSig(),
+		Round:        p.GetRound(),
+		Signature:    p.GetSignature(),
+		PreviousSig:  p.GetPreviousSig(),
+		UnchainedSig: p.GetUnchainedSig(),
 	}
 }
@@ -0,0 +1,233 @@
+package beacon
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// encoding contains the binary record format used to store beacons in the
+// boltdb store. A version 1 record is laid out as follows:
+//
+//    version (1) | flags (1) | round (8) | len(sig) (2) | sig
+//
+// followed, if the flagLinked bit is NOT set, by
+//
+//    len(prevSig) (2) | prevSig
+//
+// and then, if the flagUnchained bit is set, by
+//
+//    len(unchainedSig) (2) | unchainedSig
+//
+// When flagLinked is set, the previous signature is not duplicated: it is the
+// signature of the record stored at round - 1. Records written by earlier
+// versions of drand are JSON objects and are still transparently decoded.
+
+const (
+	// recordV1 is the version byte of the binary record format.
+	recordV1 byte = 0x01
+	// flagLinked indicates the previous signature is the signature stored at
+	// the previous round.
+	flagLinked byte = 0x01
+	// flagUnchained indicates the record ends with the unchained signature.
+	flagUnchained byte = 0x02
+	// recordHeaderLen is the size of the fixed-width part of the record.
+	recordHeaderLen = 1 + 1 + 8 + 2
+	// legacyRecordStart is the first byte of a JSON-encoded record.
+	legacyRecordStart byte = '{'
+)
+
+// ErrInvalidRecord is returned when a stored record can not be decoded.
+var ErrInvalidRecord = errors.New("invalid beacon record")
+
+// encodeRecord returns the binary record of the given beacon. If prevSig is
+// non nil and equal to the previous signature of the beacon, the record links
+// to the previous round instead of embedding the previous signature.
+func encodeRecord(b *Beacon, prevSig []byte) []byte {
+	linked := len(b.PreviousSig) > 0 && bytes.Equal(b.PreviousSig, prevSig)
+	size := recordHeaderLen + len(b.Signature)
+	if !linked {
+		size += 2 + len(b.PreviousSig)
+	}
+	if len(b.UnchainedSig) > 0 {
+		size += 2 + len(b.UnchainedSig)
+	}
+	buff := make([]byte, size)
+	buff[0] = recordV1
+	if linked {
+		buff[1] |= flagLinked
+	}
+	binary.BigEndian.PutUint64(buff[2:10], b.Round)
+	binary.BigEndian.PutUint16(buff[10:12], uint16(len(b.Signature)))
+	n := recordHeaderLen + copy(buff[recordHeaderLen:], b.Signature)
+	if !linked {
+		binary.BigEndian.PutUint16(buff[n:n+2], uint16(len(b.PreviousSig)))
+		n += 2 + copy(buff[n+2:], b.PreviousSig)
+	}
+	if len(b.UnchainedSig) > 0 {
+		buff[1] |= flagUnchained
+		binary.BigEndian.PutUint16(buff[n:n+2], uint16(len(b.UnchainedSig)))
+		copy(buff[n+2:], b.UnchainedSig)
+	}
+	return buff
+}
+
+// isLegacyRecord returns true if the record is a JSON-encoded beacon.
+func isLegacyRecord(v []byte) bool {
+	return len(v) > 0 && v[0] == legacyRecordStart
+}
+
+// isLinkedRecord returns true if the record does not embed its previous
+// signature.
+func isLinkedRecord(v []byte) bool {
+	return len(v) >= recordHeaderLen && v[0] == recordV1 && v[1]&flagLinked != 0
+}
+
+// signatureFn returns the signature stored at the given round. It is used to
+// resolve the previous signature of linked records.
+type signatureFn func(round uint64) ([]byte, error)
+
+// bucketSignatures returns a signatureFn looking up signatures in the bucket.
+func bucketSignatures(bucket *bolt.Bucket) signatureFn {
+	return func(round uint64) ([]byte, error) {
+		return signatureAt(bucket, round)
+	}
+}
+
+// decodeRecord decodes the given record. The sigAt function is used to fetch
+// the previous signature of linked records.
+func decodeRecord(v []byte, sigAt signatureFn) (*Beacon, error) {
+	if isLegacyRecord(v) {
+		b := new(Beacon)
+		return b, b.Unmarshal(v)
+	}
+	if len(v) < recordHeaderLen {
+		return nil, ErrInvalidRecord
+	}
+	if v[0] != recordV1 {
+		return nil, fmt.Errorf("unknown beacon record version %d", v[0])
+	}
+	b := &Beacon{Round: binary.BigEndian.Uint64(v[2:10])}
+	sigLen := int(binary.BigEndian.Uint16(v[10:12]))
+	rest := v[recordHeaderLen:]
+	if len(rest) < sigLen {
+		return nil, ErrInvalidRecord
+	}
+	b.Signature = copyBytes(rest[:sigLen])
+	rest = rest[sigLen:]
+	if v[1]&flagLinked != 0 {
+		if b.Round == 0 {
+			return nil, ErrInvalidRecord
+		}
+		prev, err := sigAt(b.Round - 1)
+		if err != nil {
+			return nil, err
+		}
+		b.PreviousSig = prev
+	} else {
+		prevSig, next, err := readField(rest)
+		if err != nil {
+			return nil, err
+		}
+		if len(prevSig) > 0 {
+			b.PreviousSig = prevSig
+		}
+		rest = next
+	}
+	if v[1]&flagUnchained != 0 {
+		unchainedSig, next, err := readField(rest)
+		if err != nil {
+			return nil, err
+		}
+		b.UnchainedSig = unchainedSig
+		rest = next
+	}
+	if len(rest) != 0 {
+		return nil, ErrInvalidRecord
+	}
+	return b, nil
+}
+
+// readField reads a field prefixed by its length and returns a copy of it
+// along with the remaining bytes.
+func readField(v []byte) ([]byte, []byte, error) {
+	if len(v) < 2 {
+		return nil, nil, ErrInvalidRecord
+	}
+	n := int(binary.BigEndian.Uint16(v[:2]))
+	if len(v[2:]) < n {
+		return nil, nil, ErrInvalidRecord
+	}
+	return copyBytes(v[2 : 2+n]), v[2+n:], nil
+}
+
+// signatureAt returns the signature stored at the given round, without
+// resolving its previous signature.
+func signatureAt(bucket *bolt.Bucket, round uint64) ([]byte, error) {
+	v := bucket.Get(roundToBytes(round))
+	if v == nil {
+		return nil, fmt.Errorf("linked record: no beacon stored at round %d", round)
+	}
+	if isLegacyRecord(v) {
+		b := new(Beacon)
+		if err := b.Unmarshal(v); err != nil {
+			return nil, err
+		}
+		return b.Signature, nil
+	}
+	if len(v) < recordHeaderLen {
+		return nil, ErrInvalidRecord
+	}
+	sigLen := int(binary.BigEndian.Uint16(v[10:12]))
+	if len(v) < recordHeaderLen+sigLen {
+		return nil, ErrInvalidRecord
+	}
+	return copyBytes(v[recordHeaderLen : recordHeaderLen+sigLen]), nil
+}
+
+// putRecord encodes and stores the beacon in the bucket, linking it to the
+// previous round when possible. If the record at the next round links to the
+// record being replaced, it is rewritten with its previous signature embedded
+// so it stays valid.
+func putRecord(bucket *bolt.Bucket, b *Beacon) error {
+	var prevSig []byte
+	if b.Round > 0 {
+		// no linking possible if previous round is absent or invalid
+		prevSig, _ = signatureAt(bucket, b.Round-1)
+	}
+	if err := unlinkNext(bucket, b.Round, b.Signature); err != nil {
+		return err
+	}
+	return bucket.Put(roundToBytes(b.Round), encodeRecord(b, prevSig))
+}
+
+// unlinkNext rewrites the record at round+1 with its previous signature
+// embedded if it links to the record at the given round and that record's
+// signature is about to change. A nil newSig means the record is deleted.
+func unlinkNext(bucket *bolt.Bucket, round uint64, newSig []byte) error {
+	next := bucket.Get(roundToBytes(round + 1))
+	if next == nil || !isLinkedRecord(next) {
+		return nil
+	}
+	oldSig, err := signatureAt(bucket, round)
+	if err != nil {
+		return err
+	}
+	if newSig != nil && bytes.Equal(oldSig, newSig) {
+		return nil
+	}
+	nb, err := decodeRecord(next, bucketSignatures(bucket))
+	if err != nil {
+		return err
+	}
+	return bucket.Put(roundToBytes(round+1), encodeRecord(nb, nil))
+}
+
+func copyBytes(b []byte) []byte {
+	out := make([]byte, len(b))
+	copy(out, b)
+	return out
+}
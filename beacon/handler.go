@@ -37,6 +37,9 @@ type Config struct {
 	// Callback to use when a new beacon is created - can be nil and new
 	// callbacks can be added afterwards to the beacon
 	Callback func(*Beacon)
+	// Retention defines which beacons are kept in the store - by default, the
+	// whole chain is kept
+	Retention RetentionPolicy
 }
 
 // Handler holds the logic to initiate, and react to the TBLS protocol. Each time
@@ -51,6 +54,8 @@ type Handler struct {
 	// main logic that treats incoming packet / new beacons created
 	chain  *chainStore
 	ticker *ticker
+	// the underlying store, pruned according to the retention policy
+	store Store
 
 	close     chan bool
 	addr      string
@@ -92,11 +97,15 @@ func NewHandler(c net.ProtocolClient, s Store, conf *Config, l log.Logger) (*Han
 		safe:      safe,
 		chain:     chain,
 		ticker:    ticker,
+		store:     s,
 		addr:      addr,
 		close:     make(chan bool),
 		l:         logger,
 		callbacks: callbacks,
 	}
+	if conf.Retention.Enabled() {
+		go handler.runCompactor()
+	}
 	return handler, nil
 }
 
@@ -135,6 +144,12 @@ func (h *Handler) ProcessPartialBeacon(c context.Context, p *proto.PartialBeacon
 		h.l.Error("process_partial", addr, "err", err, "prev_sig", shortSigStr(p.GetPreviousSig()), "curr_round", currentRound, "msg_sign", shortSigStr(msg), "short_pub", shortPub)
 		return nil, err
 	}
+	if len(p.GetPartialUnchainedSig()) > 0 {
+		if err := key.Scheme.VerifyPartial(info.pub, UnchainedMessage(p.GetRound()), p.GetPartialUnchainedSig()); err != nil {
+			h.l.Error("process_partial", addr, "err", err, "curr_round", currentRound, "unchained", true)
+			return nil, err
+		}
+	}
 	h.l.Debug("process_partial", addr, "prev_sig", shortSigStr(p.GetPreviousSig()), "curr_round", currentRound, "msg_sign", shortSigStr(msg), "short_pub", shortPub, "status", "OK")
 	idx, _ := key.Scheme.IndexOf(p.GetPartialSig())
 	if idx == info.index {
@@ -296,12 +311,18 @@ func (h *Handler) broadcastNextPartial(current roundInfo, upon *Beacon) {
 		h.l.Fatal("beacon_round", fmt.Sprintf("creating signature: %s", err), "round", round)
 		return
 	}
+	unchainedSig, err := key.Scheme.Sign(info.share.PrivateShare(), UnchainedMessage(round))
+	if err != nil {
+		h.l.Fatal("beacon_round", fmt.Sprintf("creating unchained signature: %s", err), "round", round)
+		return
+	}
 	shortPub := info.pub.Eval(1).V.String()[14:19]
 	h.l.Debug("broadcast_partial", round, "from_prev_sig", shortSigStr(previousSig), "msg_sign", shortSigStr(msg), "short_pub", shortPub)
 	packet := &proto.PartialBeaconPacket{
-		Round:       round,
-		PreviousSig: previousSig,
-		PartialSig:  currSig,
+		Round:               round,
+		PreviousSig:         previousSig,
+		PartialSig:          currSig,
+		PartialUnchainedSig: unchainedSig,
 	}
 	h.chain.NewValidPartial(h.addr, packet)
 	for _, id := range info.group.Nodes {
@@ -403,7 +424,7 @@ func (c *cryptoSafe) SetInfo(share *key.Share, id *key.Node, group *key.Group) {
 	if share != nil {
 		info.share = share
 		info.index = share.Share.I
-	} else {
+	} else if id != nil {
 		info.index = int(id.Index)
 	}
 	if group.TransitionTime != 0 {
@@ -435,6 +435,7 @@ func TestBeaconSimple(t *testing.T) {
 	myCallBack := func(b *Beacon) {
 		// verify partial sig
 		require.NoError(t, VerifyBeacon(bt.dpublic, b))
+		require.NotEmpty(t, b.UnchainedSig)
 		//msg := Message(b.PreviousSig, b.PreviousRound, b.Round)
 		//err := key.Scheme.VerifyRecovered(bt.dpublic, msg, b.Signature)
 		//require.NoError(t, err)
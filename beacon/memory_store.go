@@ -0,0 +1,180 @@
+package beacon
+
+import (
+	"errors"
+	"sync"
+)
+
+// memoryStore implements the Store interface with a fixed size ring buffer
+// kept in memory. It only keeps the most recent rounds: storing a round evicts
+// the round that was stored size rounds before. It is useful for ephemeral
+// relays and testing, where there is no need for the full history.
+type memoryStore struct {
+	sync.Mutex
+	ring []*Beacon
+	last *Beacon
+	len  int
+}
+
+// NewMemoryStore returns a Store that keeps the last size rounds in memory.
+// Beacons older than the oldest round the ring can hold are silently dropped.
+func NewMemoryStore(size int) (Store, error) {
+	if size < 1 {
+		return nil, errors.New("memory store: size must be positive")
+	}
+	return &memoryStore{
+		ring: make([]*Beacon, size),
+	}, nil
+}
+
+func (m *memoryStore) slot(round uint64) int {
+	return int(round % uint64(len(m.ring)))
+}
+
+// lowest returns the lowest round the ring can hold given the last round
+// stored. It must be called with the lock held.
+func (m *memoryStore) lowest() uint64 {
+	if m.last == nil || m.last.Round < uint64(len(m.ring)) {
+		return 0
+	}
+	return m.last.Round - uint64(len(m.ring)) + 1
+}
+
+// get returns the beacon stored at this round if any. It must be called with
+// the lock held.
+func (m *memoryStore) get(round uint64) *Beacon {
+	if m.last == nil || round > m.last.Round || round < m.lowest() {
+		return nil
+	}
+	b := m.ring[m.slot(round)]
+	if b == nil || b.Round != round {
+		return nil
+	}
+	return b
+}
+
+func (m *memoryStore) Len() int {
+	m.Lock()
+	defer m.Unlock()
+	return m.len
+}
+
+// Put implements the Store interface. Storing a round higher than the last one
+// evicts all rounds falling out of the ring.
+func (m *memoryStore) Put(b *Beacon) error {
+	m.Lock()
+	defer m.Unlock()
+	if m.last != nil && b.Round < m.lowest() {
+		return nil
+	}
+	if m.last == nil || b.Round > m.last.Round {
+		// evict everything falling out of the window
+		var from uint64
+		if m.last != nil {
+			from = m.last.Round + 1
+		}
+		size := uint64(len(m.ring))
+		if b.Round >= size && from < b.Round-size+1 {
+			from = b.Round - size + 1
+		}
+		for r := from; r <= b.Round; r++ {
+			if m.ring[m.slot(r)] != nil {
+				m.ring[m.slot(r)] = nil
+				m.len--
+			}
+		}
+		m.last = b
+	} else if m.get(b.Round) != nil {
+		m.len--
+	}
+	m.ring[m.slot(b.Round)] = b
+	m.len++
+	return nil
+}
+
+func (m *memoryStore) Last() (*Beacon, error) {
+	m.Lock()
+	defer m.Unlock()
+	if m.last == nil {
+		return nil, ErrNoBeaconSaved
+	}
+	return m.last, nil
+}
+
+func (m *memoryStore) Get(round uint64) (*Beacon, error) {
+	m.Lock()
+	defer m.Unlock()
+	b := m.get(round)
+	if b == nil {
+		return nil, ErrNoBeaconSaved
+	}
+	return b, nil
+}
+
+func (m *memoryStore) Del(round uint64) error {
+	m.Lock()
+	defer m.Unlock()
+	if m.get(round) == nil {
+		return nil
+	}
+	m.ring[m.slot(round)] = nil
+	m.len--
+	if m.last.Round == round {
+		low := m.lowest()
+		m.last = nil
+		for r := round; r > low; r-- {
+			if b := m.ring[m.slot(r-1)]; b != nil && b.Round == r-1 {
+				m.last = b
+				break
+			}
+		}
+	}
+	return nil
+}
+
+func (m *memoryStore) Cursor(fn func(Cursor)) {
+	m.Lock()
+	defer m.Unlock()
+	fn(&memoryCursor{m: m})
+}
+
+func (m *memoryStore) Close() {}
+
+// memoryCursor iterates over the rounds of the ring. It is only valid during
+// the Cursor call, with the lock of the store held.
+type memoryCursor struct {
+	m   *memoryStore
+	pos uint64
+}
+
+func (c *memoryCursor) First() *Beacon {
+	return c.Seek(0)
+}
+
+func (c *memoryCursor) Next() *Beacon {
+	return c.Seek(c.pos + 1)
+}
+
+func (c *memoryCursor) Seek(round uint64) *Beacon {
+	if c.m.last == nil {
+		return nil
+	}
+	if low := c.m.lowest(); round < low {
+		round = low
+	}
+	for r := round; r <= c.m.last.Round; r++ {
+		if b := c.m.get(r); b != nil {
+			c.pos = r
+			return b
+		}
+	}
+	return nil
+}
+
+func (c *memoryCursor) Last() *Beacon {
+	if c.m.last == nil {
+		return nil
+	}
+	c.pos = c.m.last.Round
+	return c.m.last
+}
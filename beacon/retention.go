@@ -0,0 +1,193 @@
+package beacon
+
+import (
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// RetentionPolicy defines how many beacons a node keeps in its store. When
+// enabled, the beacon handler periodically deletes the rounds falling out of
+// the policy. The genesis beacon is always kept. The zero value keeps the
+// whole chain.
+type RetentionPolicy struct {
+	// Rounds is the number of most recent rounds to keep. Zero means no limit
+	// on the number of rounds.
+	Rounds uint64
+	// Period is the duration during which a beacon is kept after the time of
+	// its round. Zero means no limit on the age of the beacons.
+	Period time.Duration
+}
+
+// Enabled returns true if the policy deletes any beacon.
+func (p RetentionPolicy) Enabled() bool {
+	return p.Rounds > 0 || p.Period > 0
+}
+
+// PruneRound returns the lowest round to keep given the last round stored and
+// the current time, for a chain of the given period and genesis time. When
+// both limits are set, a round is deleted as soon as it falls out of one of
+// them. The last round is always kept.
+func (p RetentionPolicy) PruneRound(last uint64, now int64, period time.Duration, genesis int64) uint64 {
+	var below uint64
+	if p.Rounds > 0 && last >= p.Rounds {
+		below = last - p.Rounds + 1
+	}
+	if p.Period > 0 {
+		cutoff := now - int64(p.Period.Seconds())
+		// keep the round that was current at the cutoff time
+		if r := CurrentRound(cutoff, period, genesis); cutoff > genesis && r > below {
+			below = r
+		}
+	}
+	if below > last {
+		below = last
+	}
+	return below
+}
+
+// ErrPruned is returned when the requested rounds have been deleted from the
+// store according to its retention policy.
+type ErrPruned struct {
+	// Below is the lowest round available, apart from the genesis beacon.
+	Below uint64
+}
+
+func (e *ErrPruned) Error() string {
+	return fmt.Sprintf("beacon: chain pruned below round %d", e.Below)
+}
+
+// lowestRound returns the lowest round stored after the genesis beacon, or 0
+// if there is none.
+func lowestRound(s Store) uint64 {
+	var lowest uint64
+	s.Cursor(func(c Cursor) {
+		if b := c.Seek(1); b != nil {
+			lowest = b.Round
+		}
+	})
+	return lowest
+}
+
+// pruneBatchSize is the number of rounds deleted per transaction when pruning
+// a boltdb store.
+var pruneBatchSize = 1000
+
+// pruner is implemented by stores that can delete a range of rounds more
+// efficiently than round by round.
+type pruner interface {
+	prune(below uint64) (int, error)
+}
+
+// PruneStore deletes all the beacons of the store from round 1 up to, but
+// excluding, the given round. The genesis beacon is kept. Rounds are deleted
+// in increasing order so the store always holds a contiguous chain after the
+// genesis beacon, even if the pruning is interrupted. It returns the number of
+// beacons deleted.
+func PruneStore(s Store, below uint64) (int, error) {
+	if p, ok := s.(pruner); ok {
+		return p.prune(below)
+	}
+	var rounds []uint64
+	s.Cursor(func(c Cursor) {
+		for b := c.Seek(1); b != nil && b.Round < below; b = c.Next() {
+			rounds = append(rounds, b.Round)
+		}
+	})
+	for i, r := range rounds {
+		if err := s.Del(r); err != nil {
+			return i, fmt.Errorf("deleting round %d: %s", r, err)
+		}
+	}
+	return len(rounds), nil
+}
+
+// prune implements the pruner interface, deleting rounds by batches of
+// pruneBatchSize.
+func (b *boltStore) prune(below uint64) (int, error) {
+	var pruned int
+	for {
+		var n int
+		err := b.db.Update(func(tx *bolt.Tx) error {
+			bucket := tx.Bucket(beaconBucket)
+			// collect the batch first since bolt cursors are invalidated by
+			// modifications
+			var keys [][]byte
+			c := bucket.Cursor()
+			for k, _ := c.Seek(roundToBytes(1)); k != nil && len(keys) < pruneBatchSize; k, _ = c.Next() {
+				if binary.BigEndian.Uint64(k) >= below {
+					break
+				}
+				keys = append(keys, copyBytes(k))
+			}
+			if len(keys) == 0 {
+				return nil
+			}
+			// the first round kept must not link to a deleted record
+			if err := unlinkNext(bucket, binary.BigEndian.Uint64(keys[len(keys)-1]), nil); err != nil {
+				return err
+			}
+			for _, k := range keys {
+				if err := bucket.Delete(k); err != nil {
+					return err
+				}
+			}
+			n = len(keys)
+			return nil
+		})
+		pruned += n
+		if err != nil || n < pruneBatchSize {
+			return pruned, err
+		}
+	}
+}
+
+// prune implements the pruner interface with a single statement.
+func (s *sqlStore) prune(below uint64) (int, error) {
+	res, err := s.db.Exec("DELETE FROM beacons WHERE round > 0 AND round < $1", int64(below))
+	if err != nil {
+		return 0, err
+	}
+	n, err := res.RowsAffected()
+	return int(n), err
+}
+
+// runCompactor periodically deletes the beacons falling out of the retention
+// policy of the handler until the handler is stopped.
+func (h *Handler) runCompactor() {
+	for {
+		h.compact()
+		select {
+		case <-h.conf.Clock.After(CompactionPeriod):
+		case <-h.close:
+			return
+		}
+	}
+}
+
+// compact deletes the beacons falling out of the retention policy.
+func (h *Handler) compact() {
+	h.Lock()
+	defer h.Unlock()
+	if h.stopped {
+		return
+	}
+	last, err := h.store.Last()
+	if err != nil {
+		h.l.Error("compactor", "loading_last", "err", err)
+		return
+	}
+	below := h.conf.Retention.PruneRound(last.Round, h.conf.Clock.Now().Unix(), h.conf.Group.Period, h.conf.Group.GenesisTime)
+	if below <= 1 {
+		return
+	}
+	n, err := PruneStore(h.store, below)
+	if err != nil {
+		h.l.Error("compactor", "pruning", "below", below, "err", err)
+	}
+	if n > 0 {
+		h.l.Info("compactor", "pruned", "below", below, "deleted", n)
+	}
+}
@@ -0,0 +1,85 @@
+package beacon
+
+import (
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetentionPolicy(t *testing.T) {
+	period := 10 * time.Second
+	genesis := int64(1000)
+	// round 100 happens at genesis + 99 periods
+	now := TimeOfRound(period, genesis, 100)
+
+	var p RetentionPolicy
+	require.False(t, p.Enabled())
+	require.Equal(t, uint64(0), p.PruneRound(100, now, period, genesis))
+
+	p = RetentionPolicy{Rounds: 10}
+	require.True(t, p.Enabled())
+	require.Equal(t, uint64(91), p.PruneRound(100, now, period, genesis))
+	require.Equal(t, uint64(0), p.PruneRound(5, now, period, genesis))
+
+	p = RetentionPolicy{Period: 5 * period}
+	require.Equal(t, uint64(95), p.PruneRound(100, now, period, genesis))
+	// the last round is always kept
+	require.Equal(t, uint64(50), p.PruneRound(50, now, period, genesis))
+	// nothing to prune right after genesis
+	require.Equal(t, uint64(0), p.PruneRound(2, genesis+int64(period.Seconds()), period, genesis))
+
+	p = RetentionPolicy{Rounds: 20, Period: 5 * period}
+	require.Equal(t, uint64(95), p.PruneRound(100, now, period, genesis))
+	p = RetentionPolicy{Rounds: 2, Period: 5 * period}
+	require.Equal(t, uint64(99), p.PruneRound(100, now, period, genesis))
+}
+
+func TestPruneStore(t *testing.T) {
+	tmp := path.Join(os.TempDir(), "drandtest")
+	require.NoError(t, os.MkdirAll(tmp, 0755))
+	defer os.RemoveAll(tmp)
+
+	pruneBatchSize = 3
+	defer func() { pruneBatchSize = 1000 }()
+	_, chain := testChain(t, 3, 2, 10)
+
+	bolt, err := NewBoltStore(tmp, nil)
+	require.NoError(t, err)
+	defer bolt.Close()
+	sqlite, err := NewSQLiteStore(tmp)
+	require.NoError(t, err)
+	defer sqlite.Close()
+	memory, err := NewMemoryStore(20)
+	require.NoError(t, err)
+
+	for _, store := range []Store{bolt, sqlite, memory} {
+		for _, b := range chain {
+			require.NoError(t, store.Put(b))
+		}
+		require.Equal(t, uint64(1), lowestRound(store))
+		n, err := PruneStore(store, 7)
+		require.NoError(t, err)
+		require.Equal(t, 6, n)
+		require.Equal(t, 4, store.Len())
+		require.Equal(t, uint64(7), lowestRound(store))
+
+		// genesis is kept and the remaining chain is intact
+		b, err := store.Get(0)
+		require.NoError(t, err)
+		require.True(t, chain[0].Equal(b))
+		for _, expected := range chain[7:] {
+			b, err := store.Get(expected.Round)
+			require.NoError(t, err)
+			require.True(t, expected.Equal(b))
+		}
+		_, err = store.Get(6)
+		require.Equal(t, ErrNoBeaconSaved, err)
+
+		n, err = PruneStore(store, 7)
+		require.NoError(t, err)
+		require.Equal(t, 0, n)
+	}
+}
@@ -0,0 +1,210 @@
+package beacon
+
+import (
+	"database/sql"
+	"path"
+
+	"github.com/nikkolasg/slog"
+)
+
+// sqlStore implements the Store interface on top of a SQL database. The schema
+// and queries only use the subset of SQL common to SQLite and PostgreSQL so
+// the chain can be inspected with standard SQL tooling.
+type sqlStore struct {
+	db *sql.DB
+}
+
+// SQLiteFileName is the name of the file the SQLite store writes to
+const SQLiteFileName = "drand.sqlite"
+
+// SQLiteDriver is the name of the SQLite driver used by NewSQLiteStore. The
+// driver must be registered by the calling package.
+const SQLiteDriver = "sqlite"
+
+// sqlCursorPage is the number of rows fetched at once by a SQL cursor.
+var sqlCursorPage = 100
+
+const sqlSchema = `CREATE TABLE IF NOT EXISTS beacons (
+	round BIGINT NOT NULL PRIMARY KEY,
+	signature BYTEA NOT NULL,
+	previous_sig BYTEA,
+	unchained_sig BYTEA
+)`
+
+// sqlAddUnchained extends the tables created before the unchained signature.
+const sqlAddUnchained = `ALTER TABLE beacons ADD COLUMN unchained_sig BYTEA`
+
+// NewSQLStore returns a Store implementation using the database reachable
+// with the given driver and data source name. The driver must be registered
+// by the calling package. The beacons table is created if it does not exist.
+func NewSQLStore(driver, dsn string) (Store, error) {
+	db, err := sql.Open(driver, dsn)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := db.Exec(sqlSchema); err != nil {
+		db.Close()
+		return nil, err
+	}
+	if _, err := db.Exec("SELECT unchained_sig FROM beacons LIMIT 1"); err != nil {
+		if _, err := db.Exec(sqlAddUnchained); err != nil {
+			db.Close()
+			return nil, err
+		}
+	}
+	return &sqlStore{db: db}, nil
+}
+
+// NewSQLiteStore returns a Store implementation using a SQLite database stored
+// in the given folder.
+func NewSQLiteStore(folder string) (Store, error) {
+	store, err := NewSQLStore(SQLiteDriver, path.Join(folder, SQLiteFileName))
+	if err != nil {
+		return nil, err
+	}
+	// SQLite only supports one writer at a time
+	store.(*sqlStore).db.SetMaxOpenConns(1)
+	return store, nil
+}
+
+func (s *sqlStore) Len() int {
+	var length int
+	if err := s.db.QueryRow("SELECT COUNT(*) FROM beacons").Scan(&length); err != nil {
+		slog.Debugf("sql store: %s", err)
+	}
+	return length
+}
+
+// Put implements the Store interface. It overwrites any beacon previously
+// stored at the same round.
+func (s *sqlStore) Put(b *Beacon) error {
+	_, err := s.db.Exec(`INSERT INTO beacons (round, signature, previous_sig, unchained_sig)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (round) DO UPDATE SET
+		signature = excluded.signature, previous_sig = excluded.previous_sig,
+		unchained_sig = excluded.unchained_sig`,
+		int64(b.Round), b.Signature, b.PreviousSig, b.UnchainedSig)
+	return err
+}
+
+func (s *sqlStore) Last() (*Beacon, error) {
+	return s.queryOne("SELECT round, signature, previous_sig, unchained_sig FROM beacons ORDER BY round DESC LIMIT 1")
+}
+
+func (s *sqlStore) Get(round uint64) (*Beacon, error) {
+	return s.queryOne("SELECT round, signature, previous_sig, unchained_sig FROM beacons WHERE round = $1", int64(round))
+}
+
+func (s *sqlStore) Del(round uint64) error {
+	_, err := s.db.Exec("DELETE FROM beacons WHERE round = $1", int64(round))
+	return err
+}
+
+func (s *sqlStore) Cursor(fn func(Cursor)) {
+	fn(&sqlCursor{s: s})
+}
+
+func (s *sqlStore) Close() {
+	if err := s.db.Close(); err != nil {
+		slog.Debugf("sql store: %s", err)
+	}
+}
+
+func (s *sqlStore) queryOne(query string, args ...interface{}) (*Beacon, error) {
+	b, err := scanBeacon(s.db.QueryRow(query, args...))
+	if err == sql.ErrNoRows {
+		return nil, ErrNoBeaconSaved
+	}
+	return b, err
+}
+
+// query returns all the beacons returned by the given query, in order.
+func (s *sqlStore) query(query string, args ...interface{}) ([]*Beacon, error) {
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var beacons []*Beacon
+	for rows.Next() {
+		b, err := scanBeacon(rows)
+		if err != nil {
+			return nil, err
+		}
+		beacons = append(beacons, b)
+	}
+	return beacons, rows.Err()
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanBeacon(row scanner) (*Beacon, error) {
+	var round int64
+	b := new(Beacon)
+	if err := row.Scan(&round, &b.Signature, &b.PreviousSig, &b.UnchainedSig); err != nil {
+		return nil, err
+	}
+	b.Round = uint64(round)
+	if len(b.PreviousSig) == 0 {
+		b.PreviousSig = nil
+	}
+	if len(b.UnchainedSig) == 0 {
+		b.UnchainedSig = nil
+	}
+	return b, nil
+}
+
+// sqlCursor iterates over the beacons table by pages of sqlCursorPage rows.
+type sqlCursor struct {
+	s    *sqlStore
+	page []*Beacon
+	pos  int
+}
+
+// load fetches the page of beacons starting at the given round.
+func (c *sqlCursor) load(round uint64) *Beacon {
+	page, err := c.s.query(`SELECT round, signature, previous_sig, unchained_sig FROM beacons
+		WHERE round >= $1 ORDER BY round ASC LIMIT $2`, int64(round), sqlCursorPage)
+	if err != nil {
+		slog.Debugf("sql store: cursor: %s", err)
+		page = nil
+	}
+	c.page = page
+	c.pos = 0
+	if len(page) == 0 {
+		return nil
+	}
+	return page[0]
+}
+
+func (c *sqlCursor) First() *Beacon {
+	return c.load(0)
+}
+
+func (c *sqlCursor) Next() *Beacon {
+	if len(c.page) == 0 {
+		return nil
+	}
+	if c.pos+1 < len(c.page) {
+		c.pos++
+		return c.page[c.pos]
+	}
+	return c.load(c.page[c.pos].Round + 1)
+}
+
+func (c *sqlCursor) Seek(round uint64) *Beacon {
+	return c.load(round)
+}
+
+func (c *sqlCursor) Last() *Beacon {
+	b, err := c.s.Last()
+	if err != nil {
+		c.page = nil
+		return nil
+	}
+	c.page = []*Beacon{b}
+	c.pos = 0
+	return b
+}
@@ -45,8 +45,10 @@ type Cursor interface {
 }
 
 // boldStore implements the Store interface using the kv storage boltdb (native
-// golang implementation). Internally, Beacons are stored as JSON-encoded in the
-// db file.
+// golang implementation). Internally, Beacons are stored using the binary
+// record format defined in encoding.go. JSON-encoded records written by
+// previous versions are still readable and can be converted with
+// MigrateBoltStore.
 type boltStore struct {
 	sync.Mutex
 	db  *bolt.DB
@@ -103,12 +105,7 @@ func (b *boltStore) Close() {
 func (b *boltStore) Put(beacon *Beacon) error {
 	err := b.db.Update(func(tx *bolt.Tx) error {
 		bucket := tx.Bucket(beaconBucket)
-		key := roundToBytes(beacon.Round)
-		buff, err := beacon.Marshal()
-		if err != nil {
-			return err
-		}
-		return bucket.Put(key, buff)
+		return putRecord(bucket, beacon)
 	})
 	if err != nil {
 		return err
@@ -130,8 +127,8 @@ func (b *boltStore) Last() (*Beacon, error) {
 		if v == nil {
 			return ErrNoBeaconSaved
 		}
-		b := &Beacon{}
-		if err := b.Unmarshal(v); err != nil {
+		b, err := decodeRecord(v, bucketSignatures(bucket))
+		if err != nil {
 			return err
 		}
 		beacon = b
@@ -149,8 +146,8 @@ func (b *boltStore) Get(round uint64) (*Beacon, error) {
 		if v == nil {
 			return ErrNoBeaconSaved
 		}
-		b := &Beacon{}
-		if err := b.Unmarshal(v); err != nil {
+		b, err := decodeRecord(v, bucketSignatures(bucket))
+		if err != nil {
 			return err
 		}
 		beacon = b
@@ -165,6 +162,9 @@ func (b *boltStore) Get(round uint64) (*Beacon, error) {
 func (b *boltStore) Del(round uint64) error {
 	return b.db.Update(func(tx *bolt.Tx) error {
 		bucket := tx.Bucket(beaconBucket)
+		if err := unlinkNext(bucket, round, nil); err != nil {
+			return err
+		}
 		return bucket.Delete(roundToBytes(round))
 	})
 }
@@ -182,16 +182,20 @@ type boltCursor struct {
 	*bolt.Cursor
 }
 
+func (c *boltCursor) decode(v []byte) *Beacon {
+	b, err := decodeRecord(v, bucketSignatures(c.Cursor.Bucket()))
+	if err != nil {
+		return nil
+	}
+	return b
+}
+
 func (c *boltCursor) First() *Beacon {
 	k, v := c.Cursor.First()
 	if k == nil {
 		return nil
 	}
-	b := new(Beacon)
-	if err := b.Unmarshal(v); err != nil {
-		return nil
-	}
-	return b
+	return c.decode(v)
 }
 
 func (c *boltCursor) Next() *Beacon {
@@ -199,11 +203,7 @@ func (c *boltCursor) Next() *Beacon {
 	if k == nil {
 		return nil
 	}
-	b := new(Beacon)
-	if err := b.Unmarshal(v); err != nil {
-		return nil
-	}
-	return b
+	return c.decode(v)
 }
 
 func (c *boltCursor) Seek(round uint64) *Beacon {
@@ -211,11 +211,7 @@ func (c *boltCursor) Seek(round uint64) *Beacon {
 	if k == nil {
 		return nil
 	}
-	b := new(Beacon)
-	if err := b.Unmarshal(v); err != nil {
-		return nil
-	}
-	return b
+	return c.decode(v)
 }
 
 func (c *boltCursor) Last() *Beacon {
@@ -223,11 +219,68 @@ func (c *boltCursor) Last() *Beacon {
 	if k == nil {
 		return nil
 	}
-	b := new(Beacon)
-	if err := b.Unmarshal(v); err != nil {
-		return nil
+	return c.decode(v)
+}
+
+// migrateBatchSize is the number of records converted per transaction by
+// MigrateBoltStore.
+var migrateBatchSize = 1000
+
+// MigrateBoltStore converts all JSON-encoded records of the boltdb store in the
+// given folder to the binary record format. It returns the number of records
+// converted. The store must not be in use by a running daemon.
+func MigrateBoltStore(folder string, opts *bolt.Options) (int, error) {
+	dbPath := path.Join(folder, BoltFileName)
+	db, err := bolt.Open(dbPath, 0660, opts)
+	if err != nil {
+		return 0, err
 	}
-	return b
+	defer db.Close()
+	var converted int
+	var next []byte
+	for done := false; !done; {
+		err = db.Update(func(tx *bolt.Tx) error {
+			bucket := tx.Bucket(beaconBucket)
+			if bucket == nil {
+				done = true
+				return nil
+			}
+			// collect a batch of legacy records first since bolt cursors are
+			// invalidated by modifications
+			var legacy []*Beacon
+			c := bucket.Cursor()
+			k, v := c.First()
+			if next != nil {
+				k, v = c.Seek(next)
+			}
+			for ; k != nil && len(legacy) < migrateBatchSize; k, v = c.Next() {
+				if !isLegacyRecord(v) {
+					continue
+				}
+				b := new(Beacon)
+				if err := b.Unmarshal(v); err != nil {
+					return fmt.Errorf("round %d: %s", binary.BigEndian.Uint64(k), err)
+				}
+				legacy = append(legacy, b)
+			}
+			if k == nil {
+				done = true
+			} else {
+				next = copyBytes(k)
+			}
+			for _, b := range legacy {
+				if err := putRecord(bucket, b); err != nil {
+					return err
+				}
+			}
+			converted += len(legacy)
+			return nil
+		})
+		if err != nil {
+			return converted, err
+		}
+	}
+	return converted, nil
 }
 
 type CallbackStore struct {
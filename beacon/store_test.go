@@ -7,6 +7,8 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/require"
+	bolt "go.etcd.io/bbolt"
+	_ "modernc.org/sqlite"
 )
 
 func TestStoreBoltOrder(t *testing.T) {
@@ -118,3 +120,213 @@ func TestStoreBolt(t *testing.T) {
 		}
 	})
 }
+
+func TestStoreBoltBinaryRecords(t *testing.T) {
+	tmp := path.Join(os.TempDir(), "drandtest")
+	require.NoError(t, os.MkdirAll(tmp, 0755))
+	defer os.RemoveAll(tmp)
+
+	store, err := NewBoltStore(tmp, nil)
+	require.NoError(t, err)
+
+	b0 := &Beacon{Round: 0, Signature: []byte("genesis seed")}
+	b1 := &Beacon{Round: 1, PreviousSig: b0.Signature, Signature: []byte("first")}
+	b2 := &Beacon{Round: 2, PreviousSig: b1.Signature, Signature: []byte("second"), UnchainedSig: []byte("second unchained")}
+	// not linked to the previous round
+	b4 := &Beacon{Round: 4, PreviousSig: []byte("third"), Signature: []byte("fourth"), UnchainedSig: []byte("fourth unchained")}
+	for _, b := range []*Beacon{b0, b1, b2, b4} {
+		require.NoError(t, store.Put(b))
+	}
+
+	bs := store.(*boltStore)
+	bs.db.View(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(beaconBucket)
+		require.False(t, isLinkedRecord(bucket.Get(roundToBytes(0))))
+		require.True(t, isLinkedRecord(bucket.Get(roundToBytes(1))))
+		require.True(t, isLinkedRecord(bucket.Get(roundToBytes(2))))
+		require.False(t, isLinkedRecord(bucket.Get(roundToBytes(4))))
+		return nil
+	})
+
+	for _, b := range []*Beacon{b1, b2, b4} {
+		eb, err := store.Get(b.Round)
+		require.NoError(t, err)
+		require.True(t, b.Equal(eb))
+	}
+	store.Cursor(func(c Cursor) {
+		require.True(t, b2.Equal(c.Seek(2)))
+		require.True(t, b4.Equal(c.Next()))
+	})
+
+	// deleting or overwriting a round keeps the next linked record valid
+	require.NoError(t, store.Del(1))
+	eb2, err := store.Get(2)
+	require.NoError(t, err)
+	require.True(t, b2.Equal(eb2))
+	require.NoError(t, store.Put(&Beacon{Round: 1, Signature: []byte("fork")}))
+	eb2, err = store.Get(2)
+	require.NoError(t, err)
+	require.True(t, b2.Equal(eb2))
+	store.Close()
+}
+
+func TestStoreBoltMigrate(t *testing.T) {
+	tmp := path.Join(os.TempDir(), "drandtest")
+	require.NoError(t, os.MkdirAll(tmp, 0755))
+	defer os.RemoveAll(tmp)
+
+	store, err := NewBoltStore(tmp, nil)
+	require.NoError(t, err)
+	bs := store.(*boltStore)
+
+	var beacons []*Beacon
+	var prevSig []byte
+	for i := 0; i < 10; i++ {
+		b := &Beacon{Round: uint64(i), PreviousSig: prevSig, Signature: []byte{byte(i), 0x42}}
+		beacons = append(beacons, b)
+		prevSig = b.Signature
+	}
+	// write legacy JSON records directly
+	require.NoError(t, bs.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(beaconBucket)
+		for _, b := range beacons {
+			buff, err := b.Marshal()
+			require.NoError(t, err)
+			require.NoError(t, bucket.Put(roundToBytes(b.Round), buff))
+		}
+		return nil
+	}))
+	// legacy records are still readable
+	eb, err := store.Get(5)
+	require.NoError(t, err)
+	require.True(t, beacons[5].Equal(eb))
+	store.Close()
+
+	migrateBatchSize = 3
+	defer func() { migrateBatchSize = 1000 }()
+	n, err := MigrateBoltStore(tmp, nil)
+	require.NoError(t, err)
+	require.Equal(t, len(beacons), n)
+	n, err = MigrateBoltStore(tmp, nil)
+	require.NoError(t, err)
+	require.Equal(t, 0, n)
+
+	store, err = NewBoltStore(tmp, nil)
+	require.NoError(t, err)
+	defer store.Close()
+	require.Equal(t, len(beacons), store.Len())
+	store.Cursor(func(c Cursor) {
+		i := 0
+		for b := c.First(); b != nil; b = c.Next() {
+			require.True(t, beacons[i].Equal(b))
+			i++
+		}
+		require.Equal(t, len(beacons), i)
+	})
+}
+
+// testStore checks the basic Store behavior shared by all implementations
+func testStore(t *testing.T, store Store) {
+	require.Equal(t, 0, store.Len())
+	_, err := store.Last()
+	require.Equal(t, ErrNoBeaconSaved, err)
+
+	var beacons []*Beacon
+	var prevSig []byte
+	for i := 0; i < 5; i++ {
+		b := &Beacon{Round: uint64(i), PreviousSig: prevSig, Signature: []byte{byte(i), 0x42}}
+		if i%2 == 1 {
+			b.UnchainedSig = []byte{byte(i), 0x43}
+		}
+		beacons = append(beacons, b)
+		prevSig = b.Signature
+		require.NoError(t, store.Put(b))
+	}
+	require.NoError(t, store.Put(beacons[2]))
+	require.Equal(t, len(beacons), store.Len())
+
+	last, err := store.Last()
+	require.NoError(t, err)
+	require.True(t, beacons[4].Equal(last))
+	eb, err := store.Get(3)
+	require.NoError(t, err)
+	require.True(t, beacons[3].Equal(eb))
+	_, err = store.Get(10)
+	require.Equal(t, ErrNoBeaconSaved, err)
+
+	store.Cursor(func(c Cursor) {
+		i := 0
+		for b := c.First(); b != nil; b = c.Next() {
+			require.True(t, beacons[i].Equal(b))
+			i++
+		}
+		require.Equal(t, len(beacons), i)
+		require.True(t, beacons[2].Equal(c.Seek(2)))
+		require.True(t, beacons[3].Equal(c.Next()))
+		require.True(t, beacons[4].Equal(c.Last()))
+		require.Nil(t, c.Seek(5))
+	})
+
+	require.NoError(t, store.Del(4))
+	require.Equal(t, len(beacons)-1, store.Len())
+	last, err = store.Last()
+	require.NoError(t, err)
+	require.True(t, beacons[3].Equal(last))
+}
+
+func TestStoreMemory(t *testing.T) {
+	store, err := NewMemoryStore(10)
+	require.NoError(t, err)
+	testStore(t, store)
+
+	store, err = NewMemoryStore(3)
+	require.NoError(t, err)
+	for i := 0; i < 10; i++ {
+		require.NoError(t, store.Put(&Beacon{Round: uint64(i), Signature: []byte{byte(i)}}))
+	}
+	require.Equal(t, 3, store.Len())
+	_, err = store.Get(6)
+	require.Equal(t, ErrNoBeaconSaved, err)
+	// too old, dropped
+	require.NoError(t, store.Put(&Beacon{Round: 2}))
+	require.Equal(t, 3, store.Len())
+	store.Cursor(func(c Cursor) {
+		require.Equal(t, uint64(7), c.First().Round)
+		require.Equal(t, uint64(8), c.Next().Round)
+	})
+	// gap bigger than the ring
+	require.NoError(t, store.Put(&Beacon{Round: 20}))
+	require.Equal(t, 1, store.Len())
+}
+
+func TestStoreSQLite(t *testing.T) {
+	tmp := path.Join(os.TempDir(), "drandtest")
+	require.NoError(t, os.MkdirAll(tmp, 0755))
+	defer os.RemoveAll(tmp)
+
+	sqlCursorPage = 2
+	defer func() { sqlCursorPage = 100 }()
+	store, err := NewSQLiteStore(tmp)
+	require.NoError(t, err)
+	testStore(t, store)
+	store.Close()
+
+	// data is persisted
+	store, err = NewSQLiteStore(tmp)
+	require.NoError(t, err)
+	defer store.Close()
+	require.Equal(t, 4, store.Len())
+	b, err := store.Get(0)
+	require.NoError(t, err)
+	require.Nil(t, b.PreviousSig)
+}
+
+func TestStoreBoltInterface(t *testing.T) {
+	tmp := path.Join(os.TempDir(), "drandtest")
+	require.NoError(t, os.MkdirAll(tmp, 0755))
+	defer os.RemoveAll(tmp)
+	store, err := NewBoltStore(tmp, nil)
+	require.NoError(t, err)
+	defer store.Close()
+	testStore(t, store)
+}
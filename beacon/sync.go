@@ -22,6 +22,11 @@ func (h *Handler) SyncChain(req *proto.SyncRequest, p proto.Protocol_SyncChainSe
 	if last.Round < fromRound {
 		return errors.New("no beacon stored above requested round")
 	}
+	if lowest := lowestRound(h.chain); fromRound > 0 && fromRound < lowest {
+		// let the peer pick another node to sync from
+		h.l.Debug("sync_chain_reply", addr, "from", fromRound, "pruned_below", lowest)
+		return &ErrPruned{Below: lowest}
+	}
 	defer h.l.Debug("sync_reply_leave", addr)
 	if fromRound == 0 {
 		last, err := h.chain.Last()
@@ -65,7 +70,7 @@ func syncChain(ctx context.Context, l log.Logger, safe *cryptoSafe, from *Beacon
 	go func() {
 		defer close(outCh)
 		for _, id := range ids {
-			if id.Equal(info.id) {
+			if info.id != nil && id.Equal(info.id) {
 				continue
 			}
 			request := &proto.SyncRequest{
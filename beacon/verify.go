@@ -0,0 +1,243 @@
+package beacon
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/drand/drand/key"
+	"github.com/drand/drand/log"
+	"github.com/drand/drand/net"
+)
+
+// FaultKind is the class of inconsistency found when verifying a chain.
+type FaultKind int
+
+const (
+	// FaultGap means some rounds are missing from the chain.
+	FaultGap FaultKind = iota
+	// FaultFork means a beacon does not build on the signature of the beacon
+	// stored at the previous round.
+	FaultFork
+	// FaultInvalid means a beacon signature does not verify against the group
+	// public key, or the genesis seed does not match the group.
+	FaultInvalid
+	// FaultCorrupt means some records can not be read from the store.
+	FaultCorrupt
+)
+
+func (k FaultKind) String() string {
+	switch k {
+	case FaultGap:
+		return "gap"
+	case FaultFork:
+		return "fork"
+	case FaultInvalid:
+		return "invalid"
+	case FaultCorrupt:
+		return "corrupt"
+	default:
+		return "unknown"
+	}
+}
+
+// ChainFault describes an inconsistency found in the chain, spanning the
+// rounds From to To included.
+type ChainFault struct {
+	Kind FaultKind
+	From uint64
+	To   uint64
+	Err  error
+}
+
+func (f *ChainFault) String() string {
+	rounds := fmt.Sprintf("round %d", f.From)
+	if f.To != f.From {
+		rounds = fmt.Sprintf("rounds %d-%d", f.From, f.To)
+	}
+	if f.Err != nil {
+		return fmt.Sprintf("%s at %s: %s", f.Kind, rounds, f.Err)
+	}
+	return fmt.Sprintf("%s at %s", f.Kind, rounds)
+}
+
+// ChainReport is the result of the verification of a chain.
+type ChainReport struct {
+	// Checked is the number of beacons read from the store.
+	Checked int
+	// Head is the highest round read from the store.
+	Head uint64
+	// LastValid is the highest round such that all rounds from the genesis up
+	// to it are present and valid. Note that the genesis beacon is written
+	// again by the beacon handler each time it starts.
+	LastValid uint64
+	// Faults lists all the inconsistencies found, in increasing round order.
+	Faults []*ChainFault
+}
+
+// Valid returns true if no fault has been found in the chain.
+func (r *ChainReport) Valid() bool {
+	return len(r.Faults) == 0
+}
+
+// Truncate deletes from the store all the rounds above the last valid round,
+// so the chain can be synced again from there.
+func (r *ChainReport) Truncate(s Store) error {
+	max := r.Head
+	for _, f := range r.Faults {
+		if f.To > max {
+			max = f.To
+		}
+	}
+	// delete from the head so no linked record is rewritten needlessly
+	for round := max; round > r.LastValid; round-- {
+		if err := s.Del(round); err != nil {
+			return fmt.Errorf("deleting round %d: %s", round, err)
+		}
+	}
+	return nil
+}
+
+// VerifyChain walks through all the beacons of the store and checks that they
+// form a valid chain: each round is present, builds on the previous one and
+// carries a valid signature from the group responsible for it. All the groups
+// that produced the chain, i.e. the ones before reshares, must be given. It
+// only returns an error if the chain can not be verified with these groups.
+func VerifyChain(s Store, groups ...*key.Group) (*ChainReport, error) {
+	if len(groups) == 0 {
+		return nil, errors.New("verify chain: no group given")
+	}
+	safe := newCryptoSafe()
+	for _, g := range groups {
+		safe.SetInfo(nil, nil, g)
+	}
+
+	report := new(ChainReport)
+	valid := true
+	fault := func(kind FaultKind, from, to uint64, err error) {
+		report.Faults = append(report.Faults, &ChainFault{Kind: kind, From: from, To: to, Err: err})
+		valid = false
+	}
+	var prev *Beacon
+	var err error
+	// check verifies the given beacon. linked is false if the beacon can not
+	// be linked to the previous one read, because of unreadable records.
+	check := func(b *Beacon, linked bool) bool {
+		report.Checked++
+		report.Head = b.Round
+		ok := true
+		switch {
+		case !linked:
+			ok = false
+		case prev == nil && b.Round != 0:
+			fault(FaultGap, 0, b.Round-1, nil)
+			ok = false
+		case prev != nil && b.Round > prev.Round+1:
+			fault(FaultGap, prev.Round+1, b.Round-1, nil)
+			ok = false
+		case prev != nil && !bytes.Equal(prev.Signature, b.PreviousSig):
+			fault(FaultFork, b.Round, b.Round, errors.New("previous signature does not match"))
+			ok = false
+		}
+		info, ierr := safe.GetInfo(b.Round)
+		if ierr != nil {
+			err = ierr
+			return false
+		}
+		if verr := verifyRound(info, b); verr != nil {
+			fault(FaultInvalid, b.Round, b.Round, verr)
+			ok = false
+		}
+		if ok && valid {
+			report.LastValid = b.Round
+		}
+		prev = b
+		return true
+	}
+
+	last, lastErr := s.Last()
+	s.Cursor(func(c Cursor) {
+		linked := true
+		b := c.First()
+		for {
+			for ; b != nil; b = c.Next() {
+				if !check(b, linked) {
+					return
+				}
+				linked = true
+			}
+			// the cursor stops at the end of the chain or at the first
+			// record it can not read
+			var next uint64
+			if prev != nil {
+				next = prev.Round + 1
+			}
+			if lastErr != nil && lastErr != ErrNoBeaconSaved {
+				fault(FaultCorrupt, next, next, lastErr)
+				return
+			}
+			if lastErr != nil || prev != nil && prev.Round >= last.Round {
+				return
+			}
+			// find the next readable record
+			for r := next + 1; r <= last.Round && b == nil; r++ {
+				b = c.Seek(r)
+			}
+			if b == nil {
+				fault(FaultCorrupt, next, last.Round, nil)
+				return
+			}
+			fault(FaultCorrupt, next, b.Round-1, nil)
+			linked = false
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// verifyRound verifies the beacon against the group information of its round.
+// The genesis beacon must carry the genesis seed of the group.
+func verifyRound(info *cryptoInfo, b *Beacon) error {
+	if b.Round == 0 {
+		if !bytes.Equal(b.Signature, info.group.GetGenesisSeed()) {
+			return errors.New("genesis seed does not match group")
+		}
+		return nil
+	}
+	return VerifyBeacon(info.pub.Commit(), b)
+}
+
+// SyncStore fetches from the nodes of the given groups the beacons following
+// the last beacon of the store, up to the given round, and saves them in the
+// store. Every beacon is verified before being saved. It returns the last round
+// stored.
+func SyncStore(ctx context.Context, l log.Logger, s Store, client net.ProtocolClient, toRound uint64, groups ...*key.Group) (uint64, error) {
+	if len(groups) == 0 {
+		return 0, errors.New("sync store: no group given")
+	}
+	safe := newCryptoSafe()
+	for _, g := range groups {
+		safe.SetInfo(nil, nil, g)
+	}
+	last, err := s.Last()
+	if err != nil {
+		return 0, err
+	}
+	if last.Round >= toRound {
+		return last.Round, nil
+	}
+	outCh, err := syncChain(ctx, l, safe, last, toRound, client)
+	if err != nil {
+		return last.Round, err
+	}
+	for b := range outCh {
+		if err := s.Put(b); err != nil {
+			return last.Round, err
+		}
+		last = b
+	}
+	return last.Round, nil
+}
@@ -0,0 +1,101 @@
+package beacon
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/drand/drand/key"
+	"github.com/drand/drand/test"
+	"github.com/stretchr/testify/require"
+	bolt "go.etcd.io/bbolt"
+)
+
+// testChain returns a group and a valid chain of the given length produced by
+// that group
+func testChain(t *testing.T, n, thr, length int) (*key.Group, []*Beacon) {
+	shares, commits := dkgShares(n, thr)
+	_, group := test.BatchIdentities(n)
+	group.Threshold = thr
+	group.PublicKey = &key.DistPublic{Coefficients: commits}
+	pub := group.PublicKey.PubPoly()
+	chain := []*Beacon{{Round: 0, Signature: group.GetGenesisSeed()}}
+	for i := 1; i < length; i++ {
+		prev := chain[i-1]
+		msg := Message(uint64(i), prev.Signature)
+		var partials [][]byte
+		for _, s := range shares[:thr] {
+			p, err := key.Scheme.Sign(s.PrivateShare(), msg)
+			require.NoError(t, err)
+			partials = append(partials, p)
+		}
+		sig, err := key.Scheme.Recover(pub, msg, partials, thr, n)
+		require.NoError(t, err)
+		chain = append(chain, &Beacon{Round: uint64(i), PreviousSig: prev.Signature, Signature: sig})
+	}
+	return group, chain
+}
+
+func TestVerifyChain(t *testing.T) {
+	tmp := path.Join(os.TempDir(), "drandtest")
+	require.NoError(t, os.MkdirAll(tmp, 0755))
+	defer os.RemoveAll(tmp)
+	store, err := NewBoltStore(tmp, nil)
+	require.NoError(t, err)
+	defer store.Close()
+
+	group, chain := testChain(t, 3, 2, 10)
+	for _, b := range chain {
+		require.NoError(t, store.Put(b))
+	}
+	_, err = VerifyChain(store)
+	require.Error(t, err)
+
+	report, err := VerifyChain(store, group)
+	require.NoError(t, err)
+	require.True(t, report.Valid())
+	require.Equal(t, 10, report.Checked)
+	require.Equal(t, uint64(9), report.LastValid)
+
+	// gap, fork and invalid signature
+	require.NoError(t, store.Del(3))
+	forked := *chain[6]
+	forked.PreviousSig = []byte("another previous signature")
+	require.NoError(t, store.Put(&forked))
+	invalid := *chain[8]
+	invalid.Signature = chain[7].Signature
+	require.NoError(t, store.Put(&invalid))
+
+	report, err = VerifyChain(store, group)
+	require.NoError(t, err)
+	require.False(t, report.Valid())
+	require.Equal(t, uint64(2), report.LastValid)
+	require.Equal(t, uint64(9), report.Head)
+	kinds := []FaultKind{FaultGap, FaultFork, FaultInvalid, FaultInvalid, FaultFork}
+	rounds := []uint64{3, 6, 6, 8, 9}
+	require.Len(t, report.Faults, len(kinds))
+	for i, f := range report.Faults {
+		require.Equal(t, kinds[i], f.Kind, f.String())
+		require.Equal(t, rounds[i], f.From, f.String())
+	}
+
+	// corrupt record
+	bs := store.(*boltStore)
+	require.NoError(t, bs.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(beaconBucket).Put(roundToBytes(5), []byte{0x42})
+	}))
+	report, err = VerifyChain(store, group)
+	require.NoError(t, err)
+	require.Equal(t, FaultCorrupt, report.Faults[1].Kind)
+	require.Equal(t, uint64(5), report.Faults[1].From)
+	require.Equal(t, uint64(5), report.Faults[1].To)
+	require.Equal(t, uint64(9), report.Head)
+
+	require.NoError(t, report.Truncate(store))
+	last, err := store.Last()
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), last.Round)
+	report, err = VerifyChain(store, group)
+	require.NoError(t, err)
+	require.True(t, report.Valid())
+}
@@ -1,8 +1,14 @@
 package client
 
 import (
+	"bytes"
 	"context"
+	"fmt"
+	"io"
+	"time"
 
+	"github.com/drand/drand/beacon"
+	"github.com/drand/drand/key"
 	"github.com/drand/drand/log"
 	lru "github.com/hashicorp/golang-lru"
 )
@@ -26,6 +32,36 @@ type cachingClient struct {
 
 	cache *lru.ARCCache
 	log   log.Logger
+	// verifyLinks checks new beacons link to the cached neighbouring rounds.
+	verifyLinks bool
+}
+
+// cached returns the cached beacon of the round, if any.
+func (c *cachingClient) cached(round uint64) *RandomData {
+	if val, ok := c.cache.Peek(round); ok {
+		if r, ok := val.(*RandomData); ok && r.Rnd == round {
+			return r
+		}
+	}
+	return nil
+}
+
+// checkLinks verifies the result links to the cached beacons of the
+// neighbouring rounds, if enabled.
+func (c *cachingClient) checkLinks(val Result) error {
+	r, ok := val.(*RandomData)
+	if !c.verifyLinks || !ok {
+		return nil
+	}
+	var prev *RandomData
+	if r.Rnd > 1 {
+		prev = c.cached(r.Rnd - 1)
+	}
+	if err := verifyLinks(r, prev, c.cached(r.Rnd+1)); err != nil {
+		c.log.Warn("caching_client", "beacon does not link to the cache", "err", err)
+		return err
+	}
+	return nil
 }
 
 // Get returns the randomness at `round` or an error.
@@ -35,7 +71,96 @@ func (c *cachingClient) Get(ctx context.Context, round uint64) (res Result, err
 	}
 	val, err := c.Client.Get(ctx, round)
 	if err == nil && val != nil {
+		if err := c.checkLinks(val); err != nil {
+			return nil, err
+		}
 		c.cache.Add(round, val)
 	}
 	return val, err
 }
+
+// GetAt returns the randomness of the round that was current at the given
+// time. The round is resolved by the underlying client, which also checks the
+// time is after genesis, unless it is already cached.
+func (c *cachingClient) GetAt(ctx context.Context, t time.Time) (res Result, err error) {
+	if t.After(time.Now()) {
+		return nil, beacon.ErrFutureTime
+	}
+	// times before genesis also resolve to the first round
+	if round := c.Client.RoundAt(t); round > 1 {
+		if val, ok := c.cache.Get(round); ok {
+			return val.(Result), nil
+		}
+	}
+	val, err := c.Client.GetAt(ctx, t)
+	if err == nil && val != nil {
+		if err := c.checkLinks(val); err != nil {
+			return nil, err
+		}
+		c.cache.Add(val.Round(), val)
+	}
+	return val, err
+}
+
+// GetRange implements the RangeClient interface. The range is served from the
+// cache if all its rounds are cached.
+func (c *cachingClient) GetRange(ctx context.Context, from, to uint64) ([]Result, error) {
+	if err := checkRange(from, to); err != nil {
+		return nil, err
+	}
+	results := rangeResults(from, to)
+	for round := from; round <= to; round++ {
+		val, ok := c.cache.Get(round)
+		if !ok {
+			break
+		}
+		results = append(results, val.(Result))
+	}
+	if uint64(len(results)) == to-from+1 {
+		return results, nil
+	}
+	results, err := GetRange(ctx, c.Client, from, to)
+	for i, r := range results {
+		if err := c.checkLinks(r); err != nil {
+			return results[:i], err
+		}
+		c.cache.Add(r.Round(), r)
+	}
+	return results, err
+}
+
+// seed adds to the cache the beacons of the given archive after verifying
+// them against the group. It returns the number of beacons added.
+func (c *cachingClient) seed(archive io.Reader, group *key.Group) (int, error) {
+	ar, err := beacon.NewArchiveReader(archive)
+	if err != nil {
+		return 0, err
+	}
+	if !bytes.Equal(ar.Header().GroupHash, group.Hash()) {
+		return 0, fmt.Errorf("archive produced by another group (%x vs %x)", ar.Header().GroupHash, group.Hash())
+	}
+	var n int
+	for {
+		b, err := ar.Next()
+		if err == io.EOF {
+			return n, nil
+		} else if err != nil {
+			return n, err
+		}
+		if b.Round == 0 {
+			continue
+		}
+		if err := beacon.VerifyBeacon(group.PublicKey.Key(), b); err != nil {
+			return n, fmt.Errorf("invalid beacon in archive at round %d: %s", b.Round, err)
+		}
+		c.cache.Add(b.Round, &RandomData{
+			Rnd:                b.Round,
+			Random:             b.Randomness(),
+			Signature:          b.Signature,
+			PreviousSignature:  b.PreviousSig,
+			Time:               beacon.TimeOfRound(group.Period, group.GenesisTime, b.Round),
+			UnchainedSignature: b.UnchainedSig,
+		})
+		n++
+	}
+}
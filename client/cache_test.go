@@ -1,11 +1,16 @@
 package client
 
 import (
+	"bytes"
 	"context"
 	"testing"
 	"time"
 
+	"github.com/drand/drand/beacon"
+	"github.com/drand/drand/key"
 	"github.com/drand/drand/log"
+	"github.com/drand/kyber"
+	"github.com/drand/kyber/util/random"
 )
 
 func TestCacheGet(t *testing.T) {
@@ -87,3 +92,62 @@ func TestCacheWatch(t *testing.T) {
 	case <-time.After(15 * time.Millisecond):
 	}
 }
+
+func TestCacheArchive(t *testing.T) {
+	secret := key.KeyGroup.Scalar().Pick(random.New())
+	pub := key.KeyGroup.Point().Mul(secret, nil)
+	group := &key.Group{
+		Threshold:   1,
+		Period:      time.Minute,
+		GenesisTime: time.Now().Unix(),
+		PublicKey:   &key.DistPublic{Coefficients: []kyber.Point{pub}},
+	}
+
+	var buff bytes.Buffer
+	aw, err := beacon.NewArchiveWriter(&buff, &beacon.ArchiveHeader{GroupHash: group.Hash(), From: 0, To: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	prev := &beacon.Beacon{Signature: group.GetGenesisSeed()}
+	if err := aw.Write(prev); err != nil {
+		t.Fatal(err)
+	}
+	for i := uint64(1); i <= 5; i++ {
+		sig, err := key.AuthScheme.Sign(secret, beacon.Message(i, prev.Signature))
+		if err != nil {
+			t.Fatal(err)
+		}
+		b := &beacon.Beacon{Round: i, PreviousSig: prev.Signature, Signature: sig}
+		if err := aw.Write(b); err != nil {
+			t.Fatal(err)
+		}
+		prev = b
+	}
+	if err := aw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	archive := buff.Bytes()
+
+	m := MockClientWithResults(1, 6)
+	c, _ := NewCachingClient(m, 10, log.DefaultLogger)
+	n, err := c.(*cachingClient).seed(bytes.NewReader(archive), group)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 5 {
+		t.Fatalf("expected 5 beacons from archive, got %d", n)
+	}
+	r, err := c.Get(context.Background(), 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Round() != 3 || len(m.(*MockClient).Results) != 5 {
+		t.Fatal("archived beacons should be served by cache.")
+	}
+
+	group.Threshold = 2
+	c, _ = NewCachingClient(m, 10, log.DefaultLogger)
+	if _, err := c.(*cachingClient).seed(bytes.NewReader(archive), group); err == nil {
+		t.Fatal("archive from another group should be refused.")
+	}
+}
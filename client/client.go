@@ -2,16 +2,23 @@ package client
 
 import (
 	"bytes"
+	"context"
 	"errors"
+	"io"
+	"time"
 
 	"github.com/drand/drand/key"
 	"github.com/drand/drand/log"
+	"github.com/drand/drand/net"
 )
 
-// New Creates a client with specified configuration.
+// New Creates a client with specified configuration. The client returned
+// implements io.Closer, it must be closed to release the disk cache and the
+// watcher, if they are configured.
 func New(options ...Option) (Client, error) {
 	cfg := clientConfig{
 		cacheSize: 32,
+		strict:    true,
 		log:       log.DefaultLogger,
 	}
 	for _, opt := range options {
@@ -20,25 +27,77 @@ func New(options ...Option) (Client, error) {
 		}
 	}
 
-	coreClient, err := makeClient(cfg)
+	coreClient, err := makeClient(&cfg)
 	if err != nil {
 		return nil, err
 	}
+	var closers []io.Closer
+	closeAll := func() {
+		for _, c := range closers {
+			c.Close()
+		}
+	}
+	if cfg.watcher != nil {
+		w, err := cfg.watcher(cfg.group)
+		if err != nil {
+			return nil, err
+		}
+		if c, ok := w.(io.Closer); ok {
+			closers = append(closers, c)
+		}
+		coreClient = &watcherClient{coreClient, w}
+	}
+	if cfg.cacheDir != "" {
+		disk, err := NewDiskCachingClient(coreClient, cfg.cacheDir, cfg.group, cfg.cacheDirSize, cfg.log)
+		if err != nil {
+			closeAll()
+			return nil, err
+		}
+		coreClient = disk
+		closers = append(closers, disk)
+	}
+	coreClient, err = wrapClient(coreClient, &cfg)
+	if err != nil {
+		closeAll()
+		return nil, err
+	}
+	w := newWatchAggregator(coreClient, cfg.log)
+	w.closers = closers
+	return w, nil
+}
+
+// wrapClient adds the memory cache and the ordering of a configuration on top
+// of a client.
+func wrapClient(coreClient Client, cfg *clientConfig) (Client, error) {
+	var err error
 	if cfg.cacheSize > 0 {
 		coreClient, err = NewCachingClient(coreClient, cfg.cacheSize, cfg.log)
 		if err != nil {
 			return nil, err
 		}
+		coreClient.(*cachingClient).verifyLinks = cfg.verifyLinks
+		for _, archive := range cfg.archives {
+			if _, err := coreClient.(*cachingClient).seed(archive, cfg.group); err != nil {
+				return nil, err
+			}
+		}
+	} else if len(cfg.archives) > 0 {
+		return nil, errors.New("archives can only be used with a cache")
+	} else if cfg.verifyLinks {
+		return nil, errors.New("link verification can only be used with a cache")
+	}
+	if cfg.ordered {
+		coreClient = NewOrderedClient(coreClient, cfg.onGap, cfg.log)
 	}
-	return newWatchAggregator(coreClient, cfg.log), nil
+	return coreClient, nil
 }
 
 // makeClient creates a client from a configuration.
-func makeClient(cfg clientConfig) (Client, error) {
+func makeClient(cfg *clientConfig) (Client, error) {
 	if !cfg.insecure && cfg.groupHash == nil && cfg.group == nil {
 		return nil, errors.New("No root of trust specified")
 	}
-	if len(cfg.urls) == 0 {
+	if len(cfg.urls) == 0 && len(cfg.grpcAddrs) == 0 {
 		return nil, errors.New("No points of contact specified")
 	}
 	clients := []Client{}
@@ -55,21 +114,77 @@ func makeClient(cfg clientConfig) (Client, error) {
 			if err != nil {
 				return nil, err
 			}
-			group, err := c.(*httpClient).FetchGroupInfo(cfg.groupHash)
+			group, err := c.(*httpClient).FetchGroupInfo(context.Background(), cfg.groupHash)
 			if err != nil {
 				return nil, err
 			}
 			cfg.group = group
 		}
-		c.(*httpClient).l = cfg.log
+		hc := c.(*httpClient)
+		hc.l = cfg.log
+		hc.strict = cfg.strict
+		if cfg.retry != nil {
+			hc.retry = *cfg.retry
+		}
+		if cfg.breakerThreshold != 0 {
+			hc.breaker = newCircuitBreaker(cfg.breakerThreshold, cfg.breakerCooldown)
+		}
 		clients = append(clients, c)
 	}
+	if len(cfg.grpcAddrs) > 0 {
+		grpcClients, err := makeGRPCClients(cfg)
+		if err != nil {
+			return nil, err
+		}
+		clients = append(clients, grpcClients...)
+	}
 	if len(clients) == 1 {
 		return clients[0], nil
 	}
+	if cfg.racing > 0 {
+		return NewRacingClient(clients, cfg.racing, cfg.group, cfg.log)
+	}
 	return NewPrioritizingClient(clients, cfg.groupHash, cfg.group, cfg.log)
 }
 
+// makeGRPCClients creates the clients of the gRPC endpoints of a
+// configuration, sharing the same connections pool.
+func makeGRPCClients(cfg *clientConfig) ([]Client, error) {
+	if len(cfg.grpcCerts) > 0 && !cfg.grpcTLS {
+		return nil, errors.New("certificates can only be used with TLS")
+	}
+	var conns net.PublicClient
+	if len(cfg.grpcCerts) > 0 {
+		certs := net.NewCertManager()
+		for _, path := range cfg.grpcCerts {
+			if err := certs.Add(path); err != nil {
+				return nil, err
+			}
+		}
+		conns = net.NewGrpcClientFromCertManager(certs)
+	} else {
+		conns = net.NewGrpcClient()
+	}
+	clients := make([]Client, 0, len(cfg.grpcAddrs))
+	for _, addr := range cfg.grpcAddrs {
+		var c Client
+		var err error
+		if cfg.group != nil {
+			c, err = NewGRPCClientWithGroup(addr, cfg.grpcTLS, cfg.group, conns)
+		} else {
+			c, err = NewGRPCClient(addr, cfg.grpcTLS, cfg.groupHash, conns)
+		}
+		if err != nil {
+			return nil, err
+		}
+		gc := c.(*grpcClient)
+		gc.l = cfg.log
+		cfg.group = gc.group
+		clients = append(clients, c)
+	}
+	return clients, nil
+}
+
 type clientConfig struct {
 	// URLs when specified will create an HTTP client.
 	urls []string
@@ -79,12 +194,43 @@ type clientConfig struct {
 	groupHash []byte
 	// Full group information - serves as a root of trust.
 	group *key.Group
+	// gRPC addresses of drand nodes when specified will create gRPC clients.
+	grpcAddrs []string
+	// whether the gRPC connections use TLS.
+	grpcTLS bool
+	// paths of the certificates trusted for the gRPC connections.
+	grpcCerts []string
+	// retry policy of the HTTP requests, DefaultRetryPolicy if nil.
+	retry *RetryPolicy
+	// circuit breaker parameters of HTTP endpoints, the defaults if zero.
+	breakerThreshold int
+	breakerCooldown  time.Duration
 	// getter configures the http transport parameters used when fetching randomness.
 	getter HTTPGetter
+	// strict binds responses to requests and checks the randomness.
+	strict bool
+	// verifyLinks checks new beacons link to the cached neighbouring rounds.
+	verifyLinks bool
 	// cache size - how large of a cache to keep locally.
 	cacheSize int
+	// number of endpoints each request is raced on, endpoints are tried one
+	// after the other if zero.
+	racing int
 	// customized client log.
 	log log.Logger
+	// directory of the disk cache, disabled if empty.
+	cacheDir string
+	// maximum number of beacons kept in the disk cache.
+	cacheDirSize int
+	// archives of beacons to seed the cache with.
+	archives []io.Reader
+	// watcher creates the source of new randomness for Watch, instead of the
+	// HTTP endpoints.
+	watcher WatcherCtor
+	// ordered makes Watch emit contiguous rounds, reporting skipped rounds
+	// to onGap.
+	ordered bool
+	onGap   func(*ErrGap)
 }
 
 // Option is an option configuring a client.
@@ -101,6 +247,19 @@ func WithHTTPEndpoints(urls []string) Option {
 	}
 }
 
+// WithGRPCEndpoints configures the client to fetch randomness directly from
+// the drand nodes at the given addresses over gRPC. If tls is set, connections
+// are secured with TLS, trusting the system certificates and the certificates
+// in the given files.
+func WithGRPCEndpoints(addrs []string, tls bool, certPaths ...string) Option {
+	return func(cfg *clientConfig) error {
+		cfg.grpcAddrs = append(cfg.grpcAddrs, addrs...)
+		cfg.grpcTLS = tls
+		cfg.grpcCerts = append(cfg.grpcCerts, certPaths...)
+		return nil
+	}
+}
+
 // WithHTTPGetter specifies the HTTP Client (or mocked equivalent) for fetching
 // randomness from an HTTP endpoint.
 func WithHTTPGetter(getter HTTPGetter) Option {
@@ -110,6 +269,43 @@ func WithHTTPGetter(getter HTTPGetter) Option {
 	}
 }
 
+// WithRetryPolicy configures how failed requests to HTTP endpoints are
+// retried. Retries are disabled with a zero policy.
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(cfg *clientConfig) error {
+		cfg.retry = &policy
+		return nil
+	}
+}
+
+// WithCircuitBreaker configures the circuit breaker of each HTTP endpoint: no
+// request is sent to an endpoint for the cooldown duration after threshold
+// consecutive failed requests. A negative threshold disables circuit breaking.
+func WithCircuitBreaker(threshold int, cooldown time.Duration) Option {
+	return func(cfg *clientConfig) error {
+		if threshold == 0 {
+			return errors.New("circuit breaker threshold can not be zero")
+		}
+		cfg.breakerThreshold = threshold
+		cfg.breakerCooldown = cooldown
+		return nil
+	}
+}
+
+// WithRacing configures the client to send each request concurrently to the k
+// best scoring endpoints and return the first answer, instead of trying the
+// endpoints one after the other. It only applies when several endpoints are
+// given.
+func WithRacing(k int) Option {
+	return func(cfg *clientConfig) error {
+		if k <= 0 {
+			return errors.New("racing requires at least one endpoint per request")
+		}
+		cfg.racing = k
+		return nil
+	}
+}
+
 // WithCacheSize specifies how large of a cache of randomness values should be
 // kept locally. Default 32
 func WithCacheSize(size int) Option {
@@ -119,6 +315,50 @@ func WithCacheSize(size int) Option {
 	}
 }
 
+// WithStrictVerification enables or disables the strict verification of the
+// responses of HTTP endpoints, enabled by default. In strict mode, a response
+// must hold the round requested and its randomness must be the hash of its
+// signature. Signatures are always verified.
+func WithStrictVerification(strict bool) Option {
+	return func(cfg *clientConfig) error {
+		cfg.strict = strict
+		return nil
+	}
+}
+
+// WithLinkVerification configures the client to check that every beacon
+// fetched links to the cached beacons of the neighbouring rounds, through its
+// previous signature. It requires a cache.
+func WithLinkVerification() Option {
+	return func(cfg *clientConfig) error {
+		cfg.verifyLinks = true
+		return nil
+	}
+}
+
+// WithCacheDir configures the client to store the beacons fetched in a
+// database in the given directory, so they persist across restarts. Beacons
+// are verified before being stored. If maxRounds is positive, the lowest
+// rounds are evicted to keep at most maxRounds beacons.
+func WithCacheDir(dir string, maxRounds int) Option {
+	return func(cfg *clientConfig) error {
+		cfg.cacheDir = dir
+		cfg.cacheDirSize = maxRounds
+		return nil
+	}
+}
+
+// WithOrderedWatch configures the client to watch strictly increasing and
+// contiguous rounds, fetching the rounds missed by the watch. Rounds that can't
+// be fetched are reported to onGap, or logged if onGap is nil.
+func WithOrderedWatch(onGap func(*ErrGap)) Option {
+	return func(cfg *clientConfig) error {
+		cfg.ordered = true
+		cfg.onGap = onGap
+		return nil
+	}
+}
+
 // WithLogger overrides the logging options for the client,
 // allowing specification of additional tags, or redirection / configuration
 // of logging level and output.
@@ -164,3 +404,25 @@ func WithGroup(group *key.Group) Option {
 		return nil
 	}
 }
+
+// WithArchive seeds the cache of the client with the beacons of the given
+// archive, as written by `drand util export`, so they don't need to be fetched
+// from the network. Every beacon is verified against the group before being
+// added to the cache. The cache size must be large enough to hold them.
+func WithArchive(archive io.Reader) Option {
+	return func(cfg *clientConfig) error {
+		cfg.archives = append(cfg.archives, archive)
+		return nil
+	}
+}
+
+// WithWatcher configures the client to receive new randomness from the Watcher
+// created by the given constructor instead of polling the HTTP endpoints, e.g.
+// to get randomness pushed over gossipsub. The HTTP endpoints are still used
+// to fetch the group and past rounds.
+func WithWatcher(ctor WatcherCtor) Option {
+	return func(cfg *clientConfig) error {
+		cfg.watcher = ctor
+		return nil
+	}
+}
@@ -1,7 +1,9 @@
 package client
 
 import (
+	"bytes"
 	"context"
+	"io"
 	"testing"
 	"time"
 
@@ -48,6 +50,28 @@ func TestClientMultiple(t *testing.T) {
 	}
 }
 
+func TestClientRacing(t *testing.T) {
+	addr1, hash, cancel := withServer(t)
+	defer cancel()
+	addr2, _, cancel2 := withServer(t)
+	defer cancel2()
+
+	c, e := New(WithHTTPEndpoints([]string{"http://" + addr1, "http://" + addr2}), WithGroupHash(hash), WithRacing(2), WithCacheSize(0))
+	if e != nil {
+		t.Fatal(e)
+	}
+	r, e := c.Get(context.Background(), 0)
+	if e != nil {
+		t.Fatal(e)
+	}
+	if r.Round() <= 0 {
+		t.Fatal("expected valid client")
+	}
+	if _, ok := c.(*watchAggregator).Client.(*RacingClient); !ok {
+		t.Fatal("expected requests to be raced")
+	}
+}
+
 func TestClientWithGroup(t *testing.T) {
 	c, err := New(WithGroup(key.NewGroup([]*key.Identity{}, 1, 100, time.Second)), WithHTTPEndpoints([]string{"http://nxdomain.local/"}))
 	if err != nil {
@@ -102,3 +126,97 @@ func TestClientWithoutCache(t *testing.T) {
 		t.Fatal("cache should be disabled.")
 	}
 }
+
+type chanWatcher chan Result
+
+func (w chanWatcher) Watch(ctx context.Context) <-chan Result {
+	return w
+}
+
+// closingWatcher records whether it was closed.
+type closingWatcher struct {
+	chanWatcher
+	closed bool
+}
+
+func (w *closingWatcher) Close() error {
+	w.closed = true
+	return nil
+}
+
+func TestClientWithWatcher(t *testing.T) {
+	addr, hash, cancel := withServer(t)
+	defer cancel()
+
+	pushed := &closingWatcher{chanWatcher: make(chanWatcher, 1)}
+	pushed.chanWatcher <- &RandomData{Rnd: 42}
+	close(pushed.chanWatcher)
+	var watcherGroup *key.Group
+	ctor := func(group *key.Group) (Watcher, error) {
+		watcherGroup = group
+		return pushed, nil
+	}
+	c, err := New(WithHTTPEndpoints([]string{"http://" + addr}), WithGroupHash(hash), WithWatcher(ctor))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if watcherGroup == nil || !bytes.Equal(watcherGroup.Hash(), hash) {
+		t.Fatal("watcher should be created with the group of the client")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	r, ok := <-c.Watch(ctx)
+	if !ok || r.Round() != 42 {
+		t.Fatal("expected the randomness pushed by the watcher")
+	}
+	// past rounds are still fetched over HTTP
+	if _, err := c.Get(ctx, 0); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.(io.Closer).Close(); err != nil {
+		t.Fatal(err)
+	}
+	if !pushed.closed {
+		t.Fatal("watcher should be closed with the client")
+	}
+
+	// the watcher is closed if the client can't be created
+	pushed.closed = false
+	_, err = New(WithHTTPEndpoints([]string{"http://" + addr}), WithGroupHash(hash), WithWatcher(ctor),
+		WithCacheSize(0), WithLinkVerification())
+	if err == nil {
+		t.Fatal("link verification without cache should fail")
+	}
+	if !pushed.closed {
+		t.Fatal("watcher should be closed when the client can't be created")
+	}
+}
+
+func TestClientVerifiableResult(t *testing.T) {
+	group, chain := fakeChain(t, 3)
+	server, _ := withFlakyServer(t, chain, 0, 0)
+	defer server.Close()
+
+	c, err := New(WithHTTPEndpoints([]string{server.URL, server.URL}), WithGroup(group))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the second request is served by the cache
+	for i := 0; i < 2; i++ {
+		r, err := c.Get(context.Background(), 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		v, ok := r.(VerifiableResult)
+		if !ok {
+			t.Fatal("result should hold its proof")
+		}
+		if !bytes.Equal(v.GetSignature(), chain[1].Signature) || !bytes.Equal(v.GetPreviousSignature(), chain[0].Signature) {
+			t.Fatal("unexpected signatures")
+		}
+		if v.Timestamp() != group.GenesisTime+int64(group.Period.Seconds()) {
+			t.Fatalf("unexpected timestamp %d", v.Timestamp())
+		}
+	}
+}
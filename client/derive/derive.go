@@ -0,0 +1,174 @@
+// Package derive derives unbiased values, such as integers in a range,
+// shuffles and samples, from the randomness of a drand round.
+//
+// Values are drawn from a deterministic random bit generator (DRBG) seeded by
+// the randomness of a round and a label naming the application, so that
+// different applications get independent values from the same round:
+//
+//	seed    = SHA256("drand-derive-v1" || uint64(len(label)) || label || randomness)
+//	block_i = SHA256(seed || uint64(i))   for i = 0, 1, 2, ...
+//
+// where integers are encoded as 8 bytes big-endian. The output of the DRBG is
+// the concatenation of the blocks. Integers are read from the output as
+// 8 bytes big-endian, and integers in [0,n) are obtained by rejection
+// sampling, discarding the values above the largest multiple of n. Anyone
+// knowing the randomness of the round and the label can reproduce a draw.
+package derive
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"math"
+
+	"github.com/drand/drand/client"
+)
+
+// domain separates the seeds of this package from other uses of the
+// randomness.
+const domain = "drand-derive-v1"
+
+// DRBG is a deterministic random bit generator seeded by the randomness of a
+// round. It implements io.Reader. It is not safe for concurrent use.
+type DRBG struct {
+	seed    [sha256.Size]byte
+	counter uint64
+	block   []byte
+}
+
+// New returns the DRBG of the result for the given label.
+func New(result client.Result, label string) *DRBG {
+	return NewFromRandomness(result.Randomness(), label)
+}
+
+// NewFromRandomness returns the DRBG of the randomness for the given label.
+func NewFromRandomness(randomness []byte, label string) *DRBG {
+	var length [8]byte
+	binary.BigEndian.PutUint64(length[:], uint64(len(label)))
+	h := sha256.New()
+	h.Write([]byte(domain))
+	h.Write(length[:])
+	h.Write([]byte(label))
+	h.Write(randomness)
+	d := &DRBG{}
+	copy(d.seed[:], h.Sum(nil))
+	return d
+}
+
+// Read fills p with the next bytes of the generator. It never fails.
+func (d *DRBG) Read(p []byte) (int, error) {
+	n := 0
+	for n < len(p) {
+		if len(d.block) == 0 {
+			d.next()
+		}
+		c := copy(p[n:], d.block)
+		d.block = d.block[c:]
+		n += c
+	}
+	return n, nil
+}
+
+// next computes the next block of output.
+func (d *DRBG) next() {
+	var counter [8]byte
+	binary.BigEndian.PutUint64(counter[:], d.counter)
+	d.counter++
+	h := sha256.New()
+	h.Write(d.seed[:])
+	h.Write(counter[:])
+	d.block = h.Sum(nil)
+}
+
+// Uint64 returns the next 8 bytes of the generator as an integer.
+func (d *DRBG) Uint64() uint64 {
+	var b [8]byte
+	d.Read(b[:])
+	return binary.BigEndian.Uint64(b[:])
+}
+
+// Uint64n returns a uniform integer in [0,n). It panics if n is 0.
+func (d *DRBG) Uint64n(n uint64) uint64 {
+	if n == 0 {
+		panic("derive: invalid argument to Uint64n")
+	}
+	// 2^64 mod n values at the top of the range would bias the result
+	excess := (math.MaxUint64%n + 1) % n
+	for {
+		v := d.Uint64()
+		if v <= math.MaxUint64-excess {
+			return v % n
+		}
+	}
+}
+
+// Intn returns a uniform integer in [0,n). It panics if n <= 0.
+func (d *DRBG) Intn(n int) int {
+	if n <= 0 {
+		panic("derive: invalid argument to Intn")
+	}
+	return int(d.Uint64n(uint64(n)))
+}
+
+// Shuffle shuffles n elements with the Fisher-Yates algorithm, swapping the
+// elements i and j with swap.
+func (d *DRBG) Shuffle(n int, swap func(i, j int)) {
+	if n < 0 {
+		panic("derive: invalid argument to Shuffle")
+	}
+	for i := n - 1; i > 0; i-- {
+		swap(i, d.Intn(i+1))
+	}
+}
+
+// Perm returns a uniform permutation of [0,n).
+func (d *DRBG) Perm(n int) []int {
+	perm := make([]int, n)
+	for i := range perm {
+		perm[i] = i
+	}
+	d.Shuffle(n, func(i, j int) { perm[i], perm[j] = perm[j], perm[i] })
+	return perm
+}
+
+// Sample returns k distinct integers of [0,n), uniformly chosen, in the order
+// they were drawn.
+func (d *DRBG) Sample(n, k int) ([]int, error) {
+	if n < 0 || k < 0 || k > n {
+		return nil, errors.New("derive: sample size must be between 0 and n")
+	}
+	// partial Fisher-Yates, swapping from the start of the slice
+	perm := make([]int, n)
+	for i := range perm {
+		perm[i] = i
+	}
+	for i := 0; i < k; i++ {
+		j := i + d.Intn(n-i)
+		perm[i], perm[j] = perm[j], perm[i]
+	}
+	return perm[:k], nil
+}
+
+// Weighted returns the index of an element chosen with a probability
+// proportional to its weight.
+func (d *DRBG) Weighted(weights []uint64) (int, error) {
+	var total uint64
+	for _, w := range weights {
+		if total+w < total {
+			return 0, errors.New("derive: weights overflow")
+		}
+		total += w
+	}
+	if total == 0 {
+		return 0, errors.New("derive: weights must not all be zero")
+	}
+	v := d.Uint64n(total)
+	for i, w := range weights {
+		if v < w {
+			return i, nil
+		}
+		v -= w
+	}
+	// unreachable since v < total
+	return len(weights) - 1, nil
+}
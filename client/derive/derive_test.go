@@ -0,0 +1,123 @@
+package derive
+
+import (
+	"bytes"
+	"encoding/hex"
+	"reflect"
+	"testing"
+)
+
+// vectorRandomness is the randomness the test vectors are derived from.
+const vectorRandomness = "8ba3ab1a5ee14ad8c0f0f5e6b3b2bb3b1e70d44cafe6e5c8a37ea43f59bbbb61"
+
+type result []byte
+
+func (r result) Round() uint64      { return 1 }
+func (r result) Randomness() []byte { return r }
+
+func vectorDRBG(t *testing.T, label string) *DRBG {
+	rnd, err := hex.DecodeString(vectorRandomness)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return New(result(rnd), label)
+}
+
+func TestVectors(t *testing.T) {
+	out := make([]byte, 40)
+	vectorDRBG(t, "lottery").Read(out)
+	if hex.EncodeToString(out) != "0b34f73827d129c5c8178c3153af3e7416b770e39ed10087e850b850edda077a2a2009ee67ac10e1" {
+		t.Fatalf("unexpected output %x", out)
+	}
+
+	d := vectorDRBG(t, "lottery")
+	if v := d.Uint64n(10); v != 7 {
+		t.Fatalf("unexpected Uint64n(10) %d", v)
+	}
+	if v := d.Uint64n(1000000); v != 212148 {
+		t.Fatalf("unexpected Uint64n(1000000) %d", v)
+	}
+	if v := d.Intn(6); v != 5 {
+		t.Fatalf("unexpected Intn(6) %d", v)
+	}
+
+	if perm := vectorDRBG(t, "shuffle").Perm(10); !reflect.DeepEqual(perm, []int{4, 7, 8, 9, 0, 2, 1, 3, 6, 5}) {
+		t.Fatalf("unexpected permutation %v", perm)
+	}
+
+	sample, err := vectorDRBG(t, "sample").Sample(100, 5)
+	if err != nil || !reflect.DeepEqual(sample, []int{16, 25, 66, 75, 22}) {
+		t.Fatalf("unexpected sample %v: %v", sample, err)
+	}
+
+	i, err := vectorDRBG(t, "weighted").Weighted([]uint64{1, 2, 3, 4})
+	if err != nil || i != 2 {
+		t.Fatalf("unexpected weighted choice %d: %v", i, err)
+	}
+
+	if v := vectorDRBG(t, "").Uint64(); v != 13097486449998057986 {
+		t.Fatalf("unexpected Uint64 %d", v)
+	}
+}
+
+func TestDomainSeparation(t *testing.T) {
+	a, b := make([]byte, 32), make([]byte, 32)
+	vectorDRBG(t, "a").Read(a)
+	vectorDRBG(t, "b").Read(b)
+	if bytes.Equal(a, b) {
+		t.Fatal("labels should derive different outputs")
+	}
+	// reads of any size give the same stream
+	d := vectorDRBG(t, "a")
+	c := make([]byte, 32)
+	d.Read(c[:5])
+	d.Read(c[5:])
+	if !bytes.Equal(a, c) {
+		t.Fatal("output should not depend on the size of reads")
+	}
+}
+
+func TestUniform(t *testing.T) {
+	d := vectorDRBG(t, "uniform")
+	const n, draws = 6, 60000
+	var counts [n]int
+	for i := 0; i < draws; i++ {
+		counts[d.Intn(n)]++
+	}
+	for v, c := range counts {
+		if c < draws/n*9/10 || c > draws/n*11/10 {
+			t.Fatalf("value %d drawn %d times out of %d", v, c, draws)
+		}
+	}
+	// the largest ranges are mostly rejected and must still terminate
+	if v := d.Uint64n(1<<63 + 1); v > 1<<63 {
+		t.Fatalf("value %d out of range", v)
+	}
+}
+
+func TestSampleErrors(t *testing.T) {
+	d := vectorDRBG(t, "errors")
+	if _, err := d.Sample(3, 4); err == nil {
+		t.Fatal("sample larger than the population should fail")
+	}
+	sample, err := d.Sample(4, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	seen := make(map[int]bool)
+	for _, v := range sample {
+		if v < 0 || v >= 4 || seen[v] {
+			t.Fatalf("invalid sample %v", sample)
+		}
+		seen[v] = true
+	}
+	if _, err := d.Weighted([]uint64{0, 0}); err == nil {
+		t.Fatal("zero weights should fail")
+	}
+	if _, err := d.Weighted([]uint64{1 << 63, 1 << 63}); err == nil {
+		t.Fatal("overflowing weights should fail")
+	}
+	if i, err := d.Weighted([]uint64{0, 5, 0}); err != nil || i != 1 {
+		t.Fatalf("only weighted element should be chosen, got %d: %v", i, err)
+	}
+}
@@ -0,0 +1,267 @@
+package client
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path"
+	"sync"
+	"time"
+
+	"github.com/drand/drand/beacon"
+	"github.com/drand/drand/key"
+	"github.com/drand/drand/log"
+	json "github.com/nikkolasg/hexjson"
+	bolt "go.etcd.io/bbolt"
+)
+
+// DiskCacheFileName is the name of the file the disk cache writes to.
+const DiskCacheFileName = "client-cache.db"
+
+// NewDiskCachingClient is a meta client that stores the beacons fetched in a
+// boltdb database in the given directory, so they persist across restarts.
+// Beacons are stored in a bucket per group after being verified against the
+// group. Beacons stored for another group are verified again and kept if
+// valid for the new group. If maxRounds is positive, the lowest rounds are
+// evicted to keep at most maxRounds beacons.
+func NewDiskCachingClient(client Client, dir string, group *key.Group, maxRounds int, l log.Logger) (*DiskCachingClient, error) {
+	if group == nil || group.PublicKey == nil {
+		return nil, fmt.Errorf("disk cache requires the group to verify beacons")
+	}
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return nil, err
+	}
+	db, err := bolt.Open(path.Join(dir, DiskCacheFileName), 0660, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, err
+	}
+	c := &DiskCachingClient{
+		Client: client,
+		db:     db,
+		group:  group,
+		bucket: []byte(hex.EncodeToString(group.Hash())),
+		max:    maxRounds,
+		log:    l,
+	}
+	if err := c.migrate(); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return c, nil
+}
+
+// DiskCachingClient is a client caching beacons on disk. It must be closed to
+// release the database.
+type DiskCachingClient struct {
+	Client
+
+	db     *bolt.DB
+	group  *key.Group
+	bucket []byte
+	max    int
+	log    log.Logger
+
+	// number of beacons stored, to evict without walking the bucket
+	countLk sync.Mutex
+	count   int
+}
+
+// migrate creates the bucket of the group, moves into it the beacons of other
+// groups that are valid for this group and deletes the other buckets.
+func (c *DiskCachingClient) migrate() error {
+	return c.db.Update(func(tx *bolt.Tx) error {
+		bucket, err := tx.CreateBucketIfNotExists(c.bucket)
+		if err != nil {
+			return err
+		}
+		var others [][]byte
+		err = tx.ForEach(func(name []byte, _ *bolt.Bucket) error {
+			if string(name) != string(c.bucket) {
+				others = append(others, append([]byte{}, name...))
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		for _, name := range others {
+			var kept, dropped int
+			err := tx.Bucket(name).ForEach(func(k, v []byte) error {
+				r := new(RandomData)
+				if err := json.Unmarshal(v, r); err != nil || c.verify(r) != nil {
+					dropped++
+					return nil
+				}
+				kept++
+				return bucket.Put(k, v)
+			})
+			if err != nil {
+				return err
+			}
+			if err := tx.DeleteBucket(name); err != nil {
+				return err
+			}
+			c.log.Info("disk_cache", "group changed", "from", string(name), "kept", kept, "dropped", dropped)
+		}
+		c.count = bucket.Stats().KeyN
+		return c.evict(bucket)
+	})
+}
+
+// verify checks the beacon against the group.
+func (c *DiskCachingClient) verify(r *RandomData) error {
+	if err := verifySignature(c.group.PublicKey.Key(), r); err != nil {
+		return err
+	}
+	return verifyRandomness(r)
+}
+
+// evict deletes the lowest rounds of the bucket until it holds at most max
+// beacons.
+func (c *DiskCachingClient) evict(bucket *bolt.Bucket) error {
+	if c.max <= 0 {
+		return nil
+	}
+	var keys [][]byte
+	cursor := bucket.Cursor()
+	for k, _ := cursor.First(); k != nil && c.count-len(keys) > c.max; k, _ = cursor.Next() {
+		keys = append(keys, append([]byte{}, k...))
+	}
+	for _, k := range keys {
+		if err := bucket.Delete(k); err != nil {
+			return err
+		}
+		c.count--
+	}
+	return nil
+}
+
+// load returns the stored beacon of the round, if any.
+func (c *DiskCachingClient) load(round uint64) *RandomData {
+	var r *RandomData
+	c.db.View(func(tx *bolt.Tx) error {
+		v := tx.Bucket(c.bucket).Get(roundKey(round))
+		if v == nil {
+			return nil
+		}
+		r = new(RandomData)
+		if err := json.Unmarshal(v, r); err != nil {
+			r = nil
+			return nil
+		}
+		// beacons stored by previous versions have no time
+		r.setTime(c.group)
+		return nil
+	})
+	return r
+}
+
+// store verifies and stores the results. Results that are not random data or
+// fail verification are not stored.
+func (c *DiskCachingClient) store(results ...Result) {
+	var valid []*RandomData
+	for _, res := range results {
+		r, ok := res.(*RandomData)
+		if !ok {
+			continue
+		}
+		if err := c.verify(r); err != nil {
+			c.log.Warn("disk_cache", "not storing invalid beacon", "round", r.Rnd, "err", err)
+			continue
+		}
+		valid = append(valid, r)
+	}
+	if len(valid) == 0 {
+		return
+	}
+	c.countLk.Lock()
+	defer c.countLk.Unlock()
+	err := c.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(c.bucket)
+		for _, r := range valid {
+			v, err := json.Marshal(r)
+			if err != nil {
+				return err
+			}
+			k := roundKey(r.Rnd)
+			if bucket.Get(k) == nil {
+				c.count++
+			}
+			if err := bucket.Put(k, v); err != nil {
+				return err
+			}
+		}
+		return c.evict(bucket)
+	})
+	if err != nil {
+		c.log.Warn("disk_cache", "failed to store beacons", "err", err)
+	}
+}
+
+// Get returns the randomness at `round` or an error.
+func (c *DiskCachingClient) Get(ctx context.Context, round uint64) (Result, error) {
+	if round != 0 {
+		if r := c.load(round); r != nil {
+			return r, nil
+		}
+	}
+	val, err := c.Client.Get(ctx, round)
+	if err == nil && val != nil {
+		c.store(val)
+	}
+	return val, err
+}
+
+// GetAt returns the randomness of the round that was current at the given
+// time.
+func (c *DiskCachingClient) GetAt(ctx context.Context, t time.Time) (Result, error) {
+	if t.After(time.Now()) {
+		return nil, beacon.ErrFutureTime
+	}
+	// times before genesis also resolve to the first round
+	if round := c.Client.RoundAt(t); round > 1 {
+		if r := c.load(round); r != nil {
+			return r, nil
+		}
+	}
+	val, err := c.Client.GetAt(ctx, t)
+	if err == nil && val != nil {
+		c.store(val)
+	}
+	return val, err
+}
+
+// GetRange implements the RangeClient interface. The range is served from
+// disk if all its rounds are stored.
+func (c *DiskCachingClient) GetRange(ctx context.Context, from, to uint64) ([]Result, error) {
+	if err := checkRange(from, to); err != nil {
+		return nil, err
+	}
+	results := rangeResults(from, to)
+	for round := from; round <= to; round++ {
+		r := c.load(round)
+		if r == nil {
+			break
+		}
+		results = append(results, r)
+	}
+	if uint64(len(results)) == to-from+1 {
+		return results, nil
+	}
+	results, err := GetRange(ctx, c.Client, from, to)
+	c.store(results...)
+	return results, err
+}
+
+// Close closes the database.
+func (c *DiskCachingClient) Close() error {
+	return c.db.Close()
+}
+
+func roundKey(round uint64) []byte {
+	var k [8]byte
+	binary.BigEndian.PutUint64(k[:], round)
+	return k[:]
+}
@@ -0,0 +1,123 @@
+package client
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/drand/drand/log"
+)
+
+func TestDiskCache(t *testing.T) {
+	dir, err := ioutil.TempDir("", "drand-client-cache")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	group, chain := fakeChain(t, 6)
+	invalid := *chain[5]
+	invalid.Signature = chain[4].Signature
+	inner := &mapClient{rounds: map[uint64]*RandomData{}}
+	for _, r := range chain[:5] {
+		inner.rounds[r.Rnd] = r
+	}
+	inner.rounds[6] = &invalid
+
+	c, err := NewDiskCachingClient(inner, dir, group, 4, log.DefaultLogger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for round := uint64(1); round <= 6; round++ {
+		if _, err := c.Get(context.Background(), round); err != nil {
+			t.Fatal(err)
+		}
+	}
+	inner.rounds = map[uint64]*RandomData{}
+	if _, err := c.Get(context.Background(), 1); err == nil {
+		t.Fatal("lowest round should have been evicted")
+	}
+	if _, err := c.Get(context.Background(), 6); err == nil {
+		t.Fatal("invalid beacon should not be stored")
+	}
+	c.Close()
+
+	// stored beacons persist across restarts
+	c, err = NewDiskCachingClient(inner, dir, group, 4, log.DefaultLogger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	results, err := GetRange(context.Background(), c, 2, 5)
+	if err != nil || len(results) != 4 {
+		t.Fatalf("expected rounds 2 to 5 from disk: %v", err)
+	}
+	c.Close()
+
+	// beacons are kept when the group changes but remains valid for them
+	group.Period = 2 * group.Period
+	c, err = NewDiskCachingClient(inner, dir, group, 4, log.DefaultLogger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Get(context.Background(), 5); err != nil {
+		t.Fatal(err)
+	}
+	c.Close()
+
+	// and dropped when they are invalid for the new group
+	other, _ := fakeChain(t, 1)
+	c, err = NewDiskCachingClient(inner, dir, other, 4, log.DefaultLogger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+	if _, err := c.Get(context.Background(), 5); err == nil {
+		t.Fatal("beacons of another group should be dropped")
+	}
+}
+
+func TestClientCacheDir(t *testing.T) {
+	dir, err := ioutil.TempDir("", "drand-client-cache")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	group, chain := fakeChain(t, 3)
+	server, _ := withRangeServer(t, chain)
+	defer server.Close()
+
+	c, err := New(WithHTTPEndpoints([]string{server.URL}), WithGroup(group), WithCacheDir(dir, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if _, err := GetRange(ctx, c, 1, 3); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(dir + "/" + DiskCacheFileName); err != nil {
+		t.Fatal(err)
+	}
+
+	// the database is released on close, so the cache can be opened again
+	if err := c.(io.Closer).Close(); err != nil {
+		t.Fatal(err)
+	}
+	server.Close()
+	c, err = New(WithHTTPEndpoints([]string{server.URL}), WithGroup(group), WithCacheDir(dir, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.(io.Closer).Close()
+	r, err := c.Get(ctx, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Round() != 2 {
+		t.Fatalf("expected round 2 from the disk cache, got %d", r.Round())
+	}
+}
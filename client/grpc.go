@@ -0,0 +1,225 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/drand/drand/beacon"
+	"github.com/drand/drand/key"
+	"github.com/drand/drand/log"
+	"github.com/drand/drand/net"
+	"github.com/drand/drand/protobuf/drand"
+)
+
+// NewGRPCClient creates a new client fetching randomness directly from a drand
+// node over gRPC. The group is fetched from the node and checked against the
+// group hash if given.
+func NewGRPCClient(addr string, tls bool, groupHash []byte, client net.PublicClient) (Client, error) {
+	if client == nil {
+		client = net.NewGrpcClient()
+	}
+	g := &grpcClient{
+		peer:   net.CreatePeer(addr, tls),
+		client: client,
+		l:      log.DefaultLogger,
+	}
+	group, err := g.FetchGroupInfo(context.Background(), groupHash)
+	if err != nil {
+		return nil, err
+	}
+	g.group = group
+	return g, nil
+}
+
+// NewGRPCClientWithGroup constructs a gRPC client when the group parameters are
+// already known.
+func NewGRPCClientWithGroup(addr string, tls bool, group *key.Group, client net.PublicClient) (Client, error) {
+	if client == nil {
+		client = net.NewGrpcClient()
+	}
+	return &grpcClient{
+		peer:   net.CreatePeer(addr, tls),
+		client: client,
+		group:  group,
+		l:      log.DefaultLogger,
+	}, nil
+}
+
+// grpcClient implements Client through the public gRPC API of a drand node.
+type grpcClient struct {
+	peer   net.Peer
+	client net.PublicClient
+	group  *key.Group
+	l      log.Logger
+}
+
+// FetchGroupInfo fetches the group of the node and checks it against the
+// group hash if given.
+func (g *grpcClient) FetchGroupInfo(ctx context.Context, groupHash []byte) (*key.Group, error) {
+	if g.group != nil {
+		return g.group, nil
+	}
+	protoGrp, err := g.client.Group(ctx, g.peer, &drand.GroupRequest{})
+	if err != nil {
+		return nil, err
+	}
+	grp, err := key.GroupFromProto(protoGrp)
+	if err != nil {
+		return nil, err
+	}
+	if grp.PublicKey == nil {
+		return nil, fmt.Errorf("Group does not have a valid key for validation")
+	}
+	if groupHash == nil {
+		g.l.Warn("grpc_client", "instantiated without trustroot", "groupHash", hex.EncodeToString(grp.Hash()))
+	}
+	if groupHash != nil && !bytes.Equal(grp.Hash(), groupHash) {
+		return nil, fmt.Errorf("%s does not advertise the expected drand group (%x vs %x)", g.peer.Address(), grp.Hash(), groupHash)
+	}
+	return grp, nil
+}
+
+// String returns the address of the node.
+func (g *grpcClient) String() string {
+	return g.peer.Address()
+}
+
+// verify strictly checks the beacon received in response to a request for the
+// given round, or for the latest round if it is 0, and returns it as random
+// data.
+func (g *grpcClient) verify(requested uint64, resp *drand.PublicRandResponse) (*RandomData, error) {
+	rand := &RandomData{
+		Rnd:                resp.GetRound(),
+		Random:             resp.GetRandomness(),
+		Signature:          resp.GetSignature(),
+		PreviousSignature:  resp.GetPreviousSignature(),
+		source:             g.peer.Address(),
+		UnchainedSignature: resp.GetUnchainedSignature(),
+	}
+	if err := verifyResponse(g.group.PublicKey.Key(), requested, rand, true); err != nil {
+		g.l.Warn("grpc_client", "failed to verify value", "round", rand.Rnd, "err", err)
+		return nil, err
+	}
+	rand.setTime(g.group)
+	return rand, nil
+}
+
+// Get returns a the randomness at `round` or an error.
+func (g *grpcClient) Get(ctx context.Context, round uint64) (Result, error) {
+	resp, err := g.client.PublicRand(ctx, g.peer, &drand.PublicRandRequest{Round: round})
+	if err != nil {
+		return nil, err
+	}
+	return g.verify(round, resp)
+}
+
+// GetAt returns the randomness of the round that was current at the given time.
+func (g *grpcClient) GetAt(ctx context.Context, t time.Time) (Result, error) {
+	round, err := beacon.RoundAtTime(t.Unix(), time.Now().Unix(), g.group.Period, g.group.GenesisTime)
+	if err != nil {
+		return nil, err
+	}
+	return g.Get(ctx, round)
+}
+
+// GetRange implements the RangeClient interface. It fetches the range page by
+// page and verifies the whole segment.
+func (g *grpcClient) GetRange(ctx context.Context, from, to uint64) ([]Result, error) {
+	if err := checkRange(from, to); err != nil {
+		return nil, err
+	}
+	results := rangeResults(from, to)
+	var prev *RandomData
+	for next := from; next <= to; {
+		resps, err := g.client.PublicRandRange(ctx, g.peer, &drand.PublicRandRangeRequest{From: next, To: to})
+		if err != nil {
+			return results, err
+		}
+		if len(resps) == 0 {
+			return results, fmt.Errorf("%s does not serve round %d", g.peer.Address(), next)
+		}
+		page := make([]*RandomData, len(resps))
+		for i, resp := range resps {
+			page[i] = &RandomData{
+				Rnd:                resp.GetRound(),
+				Random:             resp.GetRandomness(),
+				Signature:          resp.GetSignature(),
+				PreviousSignature:  resp.GetPreviousSignature(),
+				source:             g.peer.Address(),
+				UnchainedSignature: resp.GetUnchainedSignature(),
+			}
+		}
+		prev, err = verifySegment(g.group.PublicKey.Key(), next, prev, page)
+		if err != nil {
+			g.l.Warn("grpc_client", "failed to verify range", "err", err)
+			return results, err
+		}
+		for _, r := range page {
+			if err := verifyRandomness(r); err != nil {
+				g.l.Warn("grpc_client", "failed to verify range", "err", err)
+				return results, err
+			}
+			r.setTime(g.group)
+			results = append(results, r)
+		}
+		next = prev.Rnd + 1
+	}
+	return results, nil
+}
+
+// Watch returns new randomness as it becomes available. It sends the latest
+// randomness and then follows the stream of the node, resuming it after the
+// last round received if it is interrupted.
+func (g *grpcClient) Watch(ctx context.Context) <-chan Result {
+	ch := make(chan Result, 1)
+	latest, err := g.Get(ctx, 0)
+	if err != nil {
+		g.l.Error("grpc_client", "failed to watch", "err", err)
+		close(ch)
+		return ch
+	}
+	ch <- latest
+
+	go func() {
+		defer close(ch)
+		last := latest.Round()
+		for {
+			stream, err := g.client.PublicRandStream(ctx, g.peer, &drand.PublicRandRequest{Round: last + 1})
+			if err != nil {
+				g.l.Warn("grpc_client", "failed to open stream", "err", err)
+			} else {
+				for resp := range stream {
+					if resp.GetRound() <= last {
+						continue
+					}
+					rand, err := g.verify(0, resp)
+					if err != nil {
+						continue
+					}
+					select {
+					case ch <- rand:
+						last = rand.Rnd
+					case <-ctx.Done():
+						return
+					}
+				}
+				g.l.Warn("grpc_client", "stream interrupted", "last", last)
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(slack):
+			}
+		}
+	}()
+	return ch
+}
+
+// RoundAt will return the most recent round of randomness that will be available
+// at time for the current client.
+func (g *grpcClient) RoundAt(time time.Time) uint64 {
+	return beacon.CurrentRound(time.Unix(), g.group.Period, g.group.GenesisTime)
+}
@@ -0,0 +1,81 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/drand/drand/beacon"
+	"github.com/drand/drand/key"
+	"github.com/drand/drand/protobuf/drand"
+	"github.com/drand/drand/test/mock"
+)
+
+func withGRPCServer(t *testing.T) (string, []byte) {
+	t.Helper()
+	l, s := mock.NewMockGRPCPublicServer(":0", true)
+	go l.Start()
+	protoGroup, err := s.Group(context.Background(), &drand.GroupRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	group, err := key.GroupFromProto(protoGroup)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return l.Addr(), group.Hash()
+}
+
+func TestGRPCClient(t *testing.T) {
+	addr, hash := withGRPCServer(t)
+
+	if _, err := NewGRPCClient(addr, false, []byte{0}, nil); err == nil {
+		t.Fatal("client should not accept a group with a different hash")
+	}
+	c, err := NewGRPCClient(addr, false, hash, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	result, err := c.Get(ctx, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rd := result.(*RandomData)
+	if !bytes.Equal(rd.Randomness(), beacon.RandomnessFromSignature(rd.Signature)) {
+		t.Fatal("randomness should be derived from the signature")
+	}
+	// the mock server now serves an invalid beacon
+	if _, err := c.Get(ctx, 0); err == nil {
+		t.Fatal("invalid beacon should be rejected")
+	}
+}
+
+func TestGRPCWatch(t *testing.T) {
+	addr, hash := withGRPCServer(t)
+
+	c, err := New(WithGRPCEndpoints([]string{addr}, false), WithGroupHash(hash))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	result := c.Watch(ctx)
+	first, ok := <-result
+	if !ok || len(first.Randomness()) == 0 {
+		t.Fatal("should get randomness from watching")
+	}
+	// the beacons streamed by the mock server are invalid
+	if _, ok := <-result; ok {
+		t.Fatal("second result should fail per context timeout")
+	}
+}
+
+func TestGRPCEndpointsCerts(t *testing.T) {
+	_, err := New(WithGRPCEndpoints([]string{"127.0.0.1:1"}, false, "cert.pem"), WithGroupHash([]byte{0}))
+	if err == nil {
+		t.Fatal("certificates should require TLS")
+	}
+}
@@ -29,11 +29,14 @@ func NewHTTPClient(url string, groupHash []byte, client HTTPGetter) (Client, err
 		client = &http.Client{}
 	}
 	c := &httpClient{
-		root:   url,
-		client: client,
-		l:      log.DefaultLogger,
+		root:    url,
+		client:  client,
+		strict:  true,
+		retry:   DefaultRetryPolicy,
+		breaker: newCircuitBreaker(DefaultBreakerThreshold, DefaultBreakerCooldown),
+		l:       log.DefaultLogger,
 	}
-	group, err := c.FetchGroupInfo(groupHash)
+	group, err := c.FetchGroupInfo(context.Background(), groupHash)
 	if err != nil {
 		return nil, err
 	}
@@ -48,10 +51,13 @@ func NewHTTPClientWithGroup(url string, group *key.Group, client HTTPGetter) (Cl
 		client = &http.Client{}
 	}
 	c := &httpClient{
-		root:   url,
-		group:  group,
-		client: client,
-		l:      log.DefaultLogger,
+		root:    url,
+		group:   group,
+		client:  client,
+		strict:  true,
+		retry:   DefaultRetryPolicy,
+		breaker: newCircuitBreaker(DefaultBreakerThreshold, DefaultBreakerCooldown),
+		l:       log.DefaultLogger,
 	}
 	return c, nil
 }
@@ -61,21 +67,26 @@ type httpClient struct {
 	root   string
 	client HTTPGetter
 	group  *key.Group
-	l      log.Logger
+	// strict binds responses to requests and checks the randomness.
+	strict  bool
+	retry   RetryPolicy
+	breaker *circuitBreaker
+	l       log.Logger
 }
 
 // FetchGroupInfo attempts to initialize an httpClient when
 // it does not know the full group paramters for a drand group.
-func (h *httpClient) FetchGroupInfo(groupHash []byte) (*key.Group, error) {
+func (h *httpClient) FetchGroupInfo(ctx context.Context, groupHash []byte) (*key.Group, error) {
 	if h.group != nil {
 		return h.group, nil
 	}
 
 	// fetch the `Group` to validate connectivity.
-	groupResp, err := h.client.Get(fmt.Sprintf("%s/group", h.root))
+	groupResp, err := h.get(ctx, fmt.Sprintf("%s/group", h.root))
 	if err != nil {
 		return nil, err
 	}
+	defer groupResp.Body.Close()
 
 	protoGrp := drand.GroupPacket{}
 	if err := json.NewDecoder(groupResp.Body).Decode(&protoGrp); err != nil {
@@ -99,6 +110,11 @@ func (h *httpClient) FetchGroupInfo(groupHash []byte) (*key.Group, error) {
 	return grp, nil
 }
 
+// String returns the root URL of the relay.
+func (h *httpClient) String() string {
+	return h.root
+}
+
 // Implement textMarshaller
 func (h *httpClient) MarshalText() ([]byte, error) {
 	return json.Marshal(h)
@@ -111,6 +127,14 @@ type RandomData struct {
 	Random            []byte `json:"randomness,omitempty"`
 	Signature         []byte `json:"signature,omitempty"`
 	PreviousSignature []byte `json:"previous_signature,omitempty"`
+	// UnchainedSignature is the signature of the round alone, if the beacon
+	// carries one. It decrypts the payloads timelock encrypted to the round.
+	UnchainedSignature []byte `json:"unchained_signature,omitempty"`
+	// Time is the UNIX time at which the round was expected to be produced.
+	Time int64 `json:"timestamp,omitempty"`
+
+	// endpoint that served the random data
+	source string
 }
 
 // Round provides access to the round associatted with this random data.
@@ -123,37 +147,120 @@ func (r *RandomData) Randomness() []byte {
 	return r.Random
 }
 
+// GetSignature returns the signature of the round.
+func (r *RandomData) GetSignature() []byte {
+	return r.Signature
+}
+
+// GetPreviousSignature returns the signature of the previous round.
+func (r *RandomData) GetPreviousSignature() []byte {
+	return r.PreviousSignature
+}
+
+// Timestamp returns the UNIX time at which the round was expected to be
+// produced.
+func (r *RandomData) Timestamp() int64 {
+	return r.Time
+}
+
+// setTime sets the time of the round from the group.
+func (r *RandomData) setTime(group *key.Group) {
+	r.Time = beacon.TimeOfRound(group.Period, group.GenesisTime, r.Rnd)
+}
+
+// Source returns the endpoint that served the random data, e.g. the URL of an
+// HTTP relay, or an empty string if unknown.
+func (r *RandomData) Source() string {
+	return r.source
+}
+
 // Get returns a the randomness at `round` or an error.
 func (h *httpClient) Get(ctx context.Context, round uint64) (Result, error) {
-	randResponse, err := h.client.Get(fmt.Sprintf("%s/public/%d", h.root, round))
+	randResponse, err := h.get(ctx, fmt.Sprintf("%s/public/%d", h.root, round))
 	if err != nil {
 		return nil, err
 	}
+	defer randResponse.Body.Close()
 
-	randResp := RandomData{}
+	randResp := RandomData{source: h.root}
 	if err := json.NewDecoder(randResponse.Body).Decode(&randResp); err != nil {
 		return nil, err
 	}
-	if len(randResp.Signature) == 0 || len(randResp.PreviousSignature) == 0 {
-		return nil, fmt.Errorf("insufficent response")
-	}
-
-	b := beacon.Beacon{
-		PreviousSig: randResp.PreviousSignature,
-		Round:       randResp.Rnd,
-		Signature:   randResp.Signature,
-	}
-	if err := beacon.VerifyBeacon(h.group.PublicKey.Key(), &b); err != nil {
+	if err := verifyResponse(h.group.PublicKey.Key(), round, &randResp, h.strict); err != nil {
 		h.l.Warn("http_client", "failed to verify value", "err", err)
 		return nil, err
 	}
+	randResp.setTime(h.group)
 
 	return &randResp, nil
 }
 
-// Watch returns new randomness as it becomes available.
+// GetAt returns the randomness of the round that was current at the given time.
+func (h *httpClient) GetAt(ctx context.Context, t time.Time) (Result, error) {
+	round, err := beacon.RoundAtTime(t.Unix(), time.Now().Unix(), h.group.Period, h.group.GenesisTime)
+	if err != nil {
+		return nil, err
+	}
+	return h.Get(ctx, round)
+}
+
+// GetRange implements the RangeClient interface. It fetches the range page by
+// page and verifies the whole segment.
+func (h *httpClient) GetRange(ctx context.Context, from, to uint64) ([]Result, error) {
+	if err := checkRange(from, to); err != nil {
+		return nil, err
+	}
+	results := rangeResults(from, to)
+	var prev *RandomData
+	for next := from; next <= to; {
+		resp, err := h.get(ctx, fmt.Sprintf("%s/public/range?from=%d&to=%d", h.root, next, to))
+		if err != nil {
+			return results, err
+		}
+		var page []*RandomData
+		err = json.NewDecoder(resp.Body).Decode(&page)
+		resp.Body.Close()
+		if err != nil {
+			return results, err
+		}
+		if len(page) == 0 {
+			return results, fmt.Errorf("%s does not serve round %d", h.root, next)
+		}
+		if uint64(len(page)) > to-next+1 {
+			page = page[:to-next+1]
+		}
+		prev, err = verifySegment(h.group.PublicKey.Key(), next, prev, page)
+		if err != nil {
+			h.l.Warn("http_client", "failed to verify range", "err", err)
+			return results, err
+		}
+		for _, r := range page {
+			r.source = h.root
+			r.setTime(h.group)
+			if h.strict {
+				if err := verifyRandomness(r); err != nil {
+					h.l.Warn("http_client", "failed to verify range", "err", err)
+					return results, err
+				}
+			}
+			results = append(results, r)
+		}
+		next = prev.Rnd + 1
+	}
+	return results, nil
+}
+
+// Watch returns new randomness as it becomes available. It follows the event
+// stream of the relay if supported, and polls the relay otherwise.
 func (h *httpClient) Watch(ctx context.Context) <-chan Result {
-	return pollingWatcher(ctx, h, h.group, h.l)
+	resp, err := h.openStream(ctx, 0)
+	if err != nil {
+		h.l.Debug("http_client", "streaming unavailable, polling", "err", err)
+		return pollingWatcher(ctx, h, h.group, h.l)
+	}
+	ch := make(chan Result, 1)
+	go h.streamingWatcher(ctx, resp, ch)
+	return ch
 }
 
 // RoundAt will return the most recent round of randomness that will be available
@@ -7,6 +7,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/drand/drand/beacon"
 	dhttp "github.com/drand/drand/http"
 	"github.com/drand/drand/key"
 	"github.com/drand/drand/protobuf/drand"
@@ -16,7 +17,14 @@ import (
 
 func withServer(t *testing.T) (string, []byte, context.CancelFunc) {
 	t.Helper()
-	l, s := mock.NewMockGRPCPublicServer(":0")
+	return withMockServer(t, true)
+}
+
+// withMockServer serves the mock server through the HTTP relay. If
+// badSecondRound is set, only the first beacon served is valid.
+func withMockServer(t *testing.T, badSecondRound bool) (string, []byte, context.CancelFunc) {
+	t.Helper()
+	l, s := mock.NewMockGRPCPublicServer(":0", badSecondRound)
 	lAddr := l.Addr()
 	go l.Start()
 
@@ -82,15 +90,18 @@ func TestHTTPClient(t *testing.T) {
 	if len(result.Randomness()) == 0 {
 		t.Fatal("no randomness provided")
 	}
-	full, ok := (result).(*RandomData)
+	full, ok := (result).(VerifiableResult)
 	if !ok {
-		t.Fatal("Should be able to restore concrete type")
+		t.Fatal("Should be able to access the proof of the result")
 	}
-	if len(full.Signature) == 0 {
+	if len(full.GetSignature()) == 0 || len(full.GetPreviousSignature()) == 0 {
 		t.Fatal("no signature provided")
 	}
+	if full.Timestamp() == 0 {
+		t.Fatal("no timestamp provided")
+	}
 
-	if _, err := httpClient.Get(ctx, full.Rnd+1); err == nil {
+	if _, err := httpClient.Get(ctx, full.Round()+1); err == nil {
 		t.Fatal("round n+1 should have an invalid signature")
 	}
 }
@@ -121,3 +132,29 @@ func TestHTTPWatch(t *testing.T) {
 		t.Fatal("second result should fail per context timeout")
 	}
 }
+
+func TestHTTPGetAt(t *testing.T) {
+	addr, hash, cancel := withMockServer(t, false)
+	defer cancel()
+
+	c, err := NewHTTPClient("http://"+addr, hash, &http.Client{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	result, err := c.GetAt(ctx, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Randomness()) == 0 {
+		t.Fatal("no randomness provided")
+	}
+	if _, err := c.GetAt(ctx, time.Now().Add(-time.Hour)); err != beacon.ErrBeforeGenesis {
+		t.Fatalf("expected time before genesis to fail, got %v", err)
+	}
+	if _, err := c.GetAt(ctx, time.Now().Add(time.Hour)); err != beacon.ErrFutureTime {
+		t.Fatalf("expected time in the future to fail, got %v", err)
+	}
+}
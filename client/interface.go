@@ -12,6 +12,12 @@ type Client interface {
 	// recent known round, bounded at a minimum to the `RoundAt(time.Now())`
 	Get(ctx context.Context, round uint64) (Result, error)
 
+	// GetAt returns the randomness of the round that was current at the
+	// given time, i.e. the latest randomness available at that time. It
+	// returns beacon.ErrBeforeGenesis or beacon.ErrFutureTime if there is no
+	// such round.
+	GetAt(ctx context.Context, time time.Time) (Result, error)
+
 	// Watch returns new randomness as it becomes available.
 	Watch(ctx context.Context) <-chan Result
 
@@ -20,8 +26,36 @@ type Client interface {
 	RoundAt(time time.Time) uint64
 }
 
+// RangeClient is implemented by clients able to fetch a range of rounds more
+// efficiently than round by round. Use GetRange to fetch a range from any
+// client.
+type RangeClient interface {
+	// GetRange returns the randomness of rounds from `from` to `to` included,
+	// in order. The whole segment is verified to be a valid chain.
+	GetRange(ctx context.Context, from, to uint64) ([]Result, error)
+}
+
 // Result represents the randomness for a single drand round.
 type Result interface {
 	Round() uint64
 	Randomness() []byte
 }
+
+// VerifiableResult is a Result holding the proof of its randomness, so that it
+// can be archived and verified again later against the group.
+//
+// The accessors are named GetSignature and GetPreviousSignature rather than
+// Signature and PreviousSignature, as the exported fields of RandomData
+// already have those names. They follow the getters of the protobuf messages.
+type VerifiableResult interface {
+	Result
+	// GetSignature is the signature of the round, from which the randomness
+	// is derived.
+	GetSignature() []byte
+	// GetPreviousSignature is the signature of the previous round, chained
+	// into the signature of the round.
+	GetPreviousSignature() []byte
+	// Timestamp is the UNIX time at which the round was expected to be
+	// produced.
+	Timestamp() int64
+}
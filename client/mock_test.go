@@ -3,7 +3,13 @@ package client
 import (
 	"context"
 	"errors"
+	"testing"
 	"time"
+
+	"github.com/drand/drand/beacon"
+	"github.com/drand/drand/key"
+	"github.com/drand/kyber"
+	"github.com/drand/kyber/util/random"
 )
 
 // MockClient provide a mocked client interface
@@ -22,6 +28,11 @@ func (m *MockClient) Get(ctx context.Context, round uint64) (Result, error) {
 	return &r, nil
 }
 
+// GetAt returns the randomness of the round at the given time.
+func (m *MockClient) GetAt(ctx context.Context, time time.Time) (Result, error) {
+	return m.Get(ctx, m.RoundAt(time))
+}
+
 // Watch returns new randomness as it becomes available.
 func (m *MockClient) Watch(ctx context.Context) <-chan Result {
 	if m.WatchCh != nil {
@@ -60,3 +71,32 @@ func (r *MockResult) Randomness() []byte {
 func (r *MockResult) Round() uint64 {
 	return r.rnd
 }
+
+// fakeChain returns a group and a valid chain of the given length starting at
+// round 1, signed by the single key of the group.
+func fakeChain(t *testing.T, length int) (*key.Group, []*RandomData) {
+	secret := key.KeyGroup.Scalar().Pick(random.New())
+	pub := key.KeyGroup.Point().Mul(secret, nil)
+	group := &key.Group{
+		Threshold:   1,
+		Period:      time.Minute,
+		GenesisTime: time.Now().Unix(),
+		PublicKey:   &key.DistPublic{Coefficients: []kyber.Point{pub}},
+	}
+	var chain []*RandomData
+	prev := group.GetGenesisSeed()
+	for i := 1; i <= length; i++ {
+		sig, err := key.AuthScheme.Sign(secret, beacon.Message(uint64(i), prev))
+		if err != nil {
+			t.Fatal(err)
+		}
+		chain = append(chain, &RandomData{
+			Rnd:               uint64(i),
+			Random:            beacon.RandomnessFromSignature(sig),
+			Signature:         sig,
+			PreviousSignature: prev,
+		})
+		prev = sig
+	}
+	return group, chain
+}
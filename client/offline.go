@@ -0,0 +1,120 @@
+package client
+
+import (
+	"bytes"
+	"errors"
+
+	"github.com/BurntSushi/toml"
+	"github.com/drand/drand/key"
+	"github.com/drand/drand/protobuf/drand"
+	json "github.com/nikkolasg/hexjson"
+)
+
+// ParseGroup parses a group file, either in TOML as written by drand nodes or
+// in JSON as served by the /group endpoint of the relays.
+func ParseGroup(data []byte) (*key.Group, error) {
+	group := new(key.Group)
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '{' {
+		protoGrp := drand.GroupPacket{}
+		if err := json.Unmarshal(trimmed, &protoGrp); err != nil {
+			return nil, err
+		}
+		return key.GroupFromProto(&protoGrp)
+	}
+	tomlValue := group.TOMLValue()
+	if _, err := toml.Decode(string(data), tomlValue); err != nil {
+		return nil, err
+	}
+	if err := group.FromTOML(tomlValue); err != nil {
+		return nil, err
+	}
+	return group, nil
+}
+
+// Reasons for which a beacon fails verification.
+const (
+	ReasonSignature  = "invalid_signature"
+	ReasonRandomness = "invalid_randomness"
+	ReasonChain      = "chain_mismatch"
+)
+
+// Verification is the outcome of the offline verification of beacons.
+type Verification struct {
+	// Valid is true if all the beacons are valid.
+	Valid  bool                `json:"valid"`
+	Rounds []RoundVerification `json:"rounds"`
+}
+
+// RoundVerification is the outcome of the verification of a beacon.
+type RoundVerification struct {
+	Round uint64 `json:"round"`
+	Valid bool   `json:"valid"`
+	// Reason is one of the Reason constants if the beacon is invalid.
+	Reason string `json:"reason,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// VerifyResult verifies beacons against the group, without contacting any
+// node. The data is a beacon in JSON, as served by the /public endpoint of the
+// relays, or a JSON array of beacons of consecutive rounds, in which case the
+// whole segment must also be a valid chain. An error is only returned if the
+// data can't be parsed; invalid beacons are reported in the verification.
+func VerifyResult(group *key.Group, data []byte) (*Verification, error) {
+	if group == nil || group.PublicKey == nil {
+		return nil, errors.New("group does not have a valid key for validation")
+	}
+	var beacons []*RandomData
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '[' {
+		if err := json.Unmarshal(trimmed, &beacons); err != nil {
+			return nil, err
+		}
+	} else {
+		r := new(RandomData)
+		if err := json.Unmarshal(data, r); err != nil {
+			return nil, err
+		}
+		beacons = append(beacons, r)
+	}
+	if len(beacons) == 0 {
+		return nil, errors.New("no beacon to verify")
+	}
+
+	pub := group.PublicKey.Key()
+	v := &Verification{Valid: true}
+	for i, r := range beacons {
+		err := verifySignature(pub, r)
+		if err == nil {
+			err = verifyRandomness(r)
+		}
+		if err == nil && i > 0 {
+			prev := beacons[i-1]
+			if prev.Rnd+1 != r.Rnd {
+				err = &ErrChainMismatch{Round: r.Rnd, Neighbour: prev.Rnd}
+			} else {
+				err = verifyLinks(r, prev, nil)
+			}
+		}
+		rv := RoundVerification{Round: r.Rnd, Valid: err == nil}
+		if err != nil {
+			v.Valid = false
+			rv.Reason = reason(err)
+			rv.Error = err.Error()
+		}
+		v.Rounds = append(v.Rounds, rv)
+	}
+	return v, nil
+}
+
+// reason returns the reason of a verification error.
+func reason(err error) string {
+	var sigErr *ErrInvalidSignature
+	var randErr *ErrInvalidRandomness
+	switch {
+	case errors.As(err, &sigErr):
+		return ReasonSignature
+	case errors.As(err, &randErr):
+		return ReasonRandomness
+	default:
+		return ReasonChain
+	}
+}
@@ -0,0 +1,89 @@
+package client
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/BurntSushi/toml"
+	"github.com/drand/drand/key"
+	json "github.com/nikkolasg/hexjson"
+)
+
+func TestParseGroup(t *testing.T) {
+	group, _ := fakeChain(t, 1)
+	group.Nodes = []*key.Node{{Identity: key.NewKeyPair("127.0.0.1:8080").Public}}
+
+	var tomlGroup bytes.Buffer
+	if err := toml.NewEncoder(&tomlGroup).Encode(group.TOML()); err != nil {
+		t.Fatal(err)
+	}
+	jsonGroup, err := json.Marshal(group.ToProto())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, data := range [][]byte{tomlGroup.Bytes(), jsonGroup} {
+		parsed, err := ParseGroup(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(parsed.Hash(), group.Hash()) {
+			t.Fatal("parsed group differs")
+		}
+	}
+}
+
+func TestVerifyResult(t *testing.T) {
+	group, chain := fakeChain(t, 4)
+
+	single, err := json.Marshal(chain[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	v, err := VerifyResult(group, single)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !v.Valid || len(v.Rounds) != 1 || v.Rounds[0].Round != 2 {
+		t.Fatalf("unexpected verification %+v", v)
+	}
+
+	segment, err := json.Marshal(chain)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v, err = VerifyResult(group, segment); err != nil || !v.Valid || len(v.Rounds) != 4 {
+		t.Fatalf("unexpected verification %+v: %v", v, err)
+	}
+
+	// a gap in the segment breaks the chain
+	gap, err := json.Marshal([]*RandomData{chain[0], chain[2]})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v, err = VerifyResult(group, gap); err != nil || v.Valid || v.Rounds[1].Reason != ReasonChain {
+		t.Fatalf("unexpected verification %+v: %v", v, err)
+	}
+
+	forged := *chain[3]
+	forged.Random = []byte("forged")
+	data, err := json.Marshal(&forged)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v, err = VerifyResult(group, data); err != nil || v.Valid || v.Rounds[0].Reason != ReasonRandomness {
+		t.Fatalf("unexpected verification %+v: %v", v, err)
+	}
+
+	forged = *chain[3]
+	forged.Rnd = 5
+	if data, err = json.Marshal(&forged); err != nil {
+		t.Fatal(err)
+	}
+	if v, err = VerifyResult(group, data); err != nil || v.Valid || v.Rounds[0].Reason != ReasonSignature {
+		t.Fatalf("unexpected verification %+v: %v", v, err)
+	}
+
+	if _, err := VerifyResult(group, []byte("not json")); err == nil {
+		t.Fatal("malformed data should fail")
+	}
+}
@@ -0,0 +1,150 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/drand/drand/log"
+)
+
+// backfillAttempts is the number of times an ordered watch tries to fetch the
+// rounds of a gap before reporting it.
+const backfillAttempts = 3
+
+// ErrGap reports rounds an ordered watch skipped because they could not be
+// fetched.
+type ErrGap struct {
+	From uint64
+	To   uint64
+	Err  error
+}
+
+func (e *ErrGap) Error() string {
+	return fmt.Sprintf("client: rounds %d to %d skipped: %s", e.From, e.To, e.Err)
+}
+
+// Unwrap returns the error that prevented fetching the rounds.
+func (e *ErrGap) Unwrap() error {
+	return e.Err
+}
+
+// NewOrderedClient is a meta client whose Watch emits strictly increasing and
+// contiguous rounds. Rounds missed by the watch of the underlying client are
+// fetched with Get, and rounds received more than once or out of order are
+// dropped. Each round that can't be fetched is reported to onGap, or logged if
+// onGap is nil, before the watch resumes with the next round.
+func NewOrderedClient(c Client, onGap func(*ErrGap), l log.Logger) Client {
+	return &orderedClient{Client: c, onGap: onGap, log: l}
+}
+
+type orderedClient struct {
+	Client
+	onGap func(*ErrGap)
+	log   log.Logger
+}
+
+// GetRange implements the RangeClient interface.
+func (c *orderedClient) GetRange(ctx context.Context, from, to uint64) ([]Result, error) {
+	return GetRange(ctx, c.Client, from, to)
+}
+
+// Watch returns new randomness as it becomes available, without gaps.
+func (c *orderedClient) Watch(ctx context.Context) <-chan Result {
+	out := make(chan Result, 5)
+	go func() {
+		defer close(out)
+		send := func(r Result) bool {
+			select {
+			case out <- r:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+		in := c.Client.Watch(ctx)
+		var last uint64
+		for {
+			var r Result
+			select {
+			case res, ok := <-in:
+				if !ok {
+					return
+				}
+				r = res
+			case <-ctx.Done():
+				return
+			}
+			if r.Round() <= last {
+				continue
+			}
+			if last != 0 && r.Round() > last+1 {
+				for _, filled := range c.backfill(ctx, last+1, r.Round()-1) {
+					if !send(filled) {
+						return
+					}
+				}
+			}
+			if !send(r) {
+				return
+			}
+			last = r.Round()
+		}
+	}()
+	return out
+}
+
+// backfill fetches the rounds from `from` to `to` included. It reports each
+// round it could not fetch, skips it and goes on with the next rounds. It
+// returns the rounds fetched, in order.
+func (c *orderedClient) backfill(ctx context.Context, from, to uint64) []Result {
+	var filled []Result
+	for from <= to {
+		results, err := c.fetch(ctx, from, to)
+		filled = append(filled, results...)
+		from += uint64(len(results))
+		if from > to || ctx.Err() != nil {
+			break
+		}
+		gap := &ErrGap{From: from, To: from, Err: err}
+		if c.onGap != nil {
+			c.onGap(gap)
+		} else {
+			c.log.Warn("ordered_client", "skipped round", "round", from, "err", err)
+		}
+		from++
+	}
+	return filled
+}
+
+// fetch fetches the rounds from `from` to `to` included, retrying on errors.
+// It returns the rounds fetched following each other from `from`, and the
+// error that prevented fetching the next one, if any.
+func (c *orderedClient) fetch(ctx context.Context, from, to uint64) ([]Result, error) {
+	var fetched []Result
+	var err error
+	next := from
+	for attempt := 0; attempt < backfillAttempts && next <= to; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-time.After(DefaultRetryPolicy.backoff(attempt - 1)):
+			case <-ctx.Done():
+				return fetched, ctx.Err()
+			}
+		}
+		var results []Result
+		results, err = GetRange(ctx, c.Client, next, to)
+		for _, r := range results {
+			// results must continue the sequence to be emitted
+			if r.Round() != next {
+				break
+			}
+			fetched = append(fetched, r)
+			next++
+		}
+	}
+	if next <= to && err == nil {
+		err = fmt.Errorf("round %d not returned", next)
+	}
+	return fetched, err
+}
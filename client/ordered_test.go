@@ -0,0 +1,55 @@
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestOrderedWatch(t *testing.T) {
+	_, chain := fakeChain(t, 12)
+	rounds := make(map[uint64]*RandomData)
+	for _, r := range chain {
+		rounds[r.Rnd] = r
+	}
+	// round 6 can't be fetched
+	delete(rounds, 6)
+	watchCh := make(chan Result, 10)
+	inner := &mapClient{MockClient: MockClient{WatchCh: watchCh}, rounds: rounds}
+
+	gaps := make(chan *ErrGap, 1)
+	c := NewOrderedClient(inner, func(gap *ErrGap) { gaps <- gap }, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	out := c.Watch(ctx)
+	// a gap, a duplicate, an older round, a gap and a gap with an
+	// unrecoverable round in the middle
+	for _, round := range []uint64{1, 3, 3, 2, 5, 9, 10} {
+		watchCh <- chain[round-1]
+	}
+
+	for _, expected := range []uint64{1, 2, 3, 4, 5, 7, 8, 9, 10} {
+		select {
+		case r := <-out:
+			if r.Round() != expected {
+				t.Fatalf("expected round %d, got %d", expected, r.Round())
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("round %d not received", expected)
+		}
+	}
+	select {
+	case gap := <-gaps:
+		if gap.From != 6 || gap.To != 6 || gap.Err == nil {
+			t.Fatalf("unexpected gap %v", gap)
+		}
+	default:
+		t.Fatal("gap should be reported")
+	}
+
+	close(watchCh)
+	if _, ok := <-out; ok {
+		t.Fatal("watch should end with the underlying watch")
+	}
+}
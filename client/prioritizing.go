@@ -5,6 +5,7 @@ import (
 	"errors"
 	"time"
 
+	"github.com/drand/drand/beacon"
 	"github.com/drand/drand/key"
 	"github.com/drand/drand/log"
 )
@@ -42,6 +43,44 @@ func (p *prioritizingClient) Get(ctx context.Context, round uint64) (res Result,
 	return
 }
 
+// GetAt returns the randomness of the round that was current at the given time.
+func (p *prioritizingClient) GetAt(ctx context.Context, t time.Time) (res Result, err error) {
+	for i, c := range p.Clients {
+		res, err = c.GetAt(ctx, t)
+		if err == nil {
+			// previous clients failed. move them to end of priority.
+			if i > 0 {
+				p.Clients = append(p.Clients[i:], p.Clients[:i]...)
+			}
+			return
+		}
+		// context deadline hit or no round at that time
+		if ctx.Err() != nil || err == beacon.ErrBeforeGenesis || err == beacon.ErrFutureTime {
+			return
+		}
+	}
+	return
+}
+
+// GetRange implements the RangeClient interface.
+func (p *prioritizingClient) GetRange(ctx context.Context, from, to uint64) (res []Result, err error) {
+	for i, c := range p.Clients {
+		res, err = GetRange(ctx, c, from, to)
+		if err == nil {
+			// previous clients failed. move them to end of priority.
+			if i > 0 {
+				p.Clients = append(p.Clients[i:], p.Clients[:i]...)
+			}
+			return
+		}
+		// context deadline hit
+		if ctx.Err() != nil {
+			return
+		}
+	}
+	return
+}
+
 // Attempt to learn the trust root for the group from group-hash.
 func (p *prioritizingClient) learnGroup(ctx context.Context) error {
 	var group *key.Group
@@ -49,7 +88,7 @@ func (p *prioritizingClient) learnGroup(ctx context.Context) error {
 
 	for _, c := range p.Clients {
 		if hc, ok := c.(*httpClient); ok {
-			group, err = hc.FetchGroupInfo(p.groupHash)
+			group, err = hc.FetchGroupInfo(ctx, p.groupHash)
 			if err == nil {
 				p.group = group
 				return nil
@@ -62,7 +101,9 @@ func (p *prioritizingClient) learnGroup(ctx context.Context) error {
 	return err
 }
 
-// Watch returns new randomness as it becomes available.
+// Watch returns new randomness as it becomes available. It follows the watch
+// of the sub-clients in priority order, e.g. their native streams, failing
+// over to the next one if it ends or stalls.
 func (p *prioritizingClient) Watch(ctx context.Context) <-chan Result {
 	if p.group == nil {
 		if err := p.learnGroup(ctx); err != nil {
@@ -72,7 +113,9 @@ func (p *prioritizingClient) Watch(ctx context.Context) <-chan Result {
 			return ch
 		}
 	}
-	return pollingWatcher(ctx, p, p.group, p.log)
+	return failoverWatcher(ctx, func() []Client {
+		return append([]Client{}, p.Clients...)
+	}, p.group, p.log)
 }
 
 // RoundAt will return the most recent round of randomness that will be available
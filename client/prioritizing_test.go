@@ -83,3 +83,28 @@ func TestPrioritizingWatchFromClient(t *testing.T) {
 		t.Fatal("wrong client prioritized")
 	}
 }
+
+func TestPrioritizingWatchFailover(t *testing.T) {
+	first := &MockClient{WatchCh: make(chan Result, 2)}
+	first.WatchCh <- &MockResult{1, []byte{1}}
+	first.WatchCh <- &MockResult{2, []byte{2}}
+	close(first.WatchCh)
+	second := &MockClient{WatchCh: make(chan Result, 2)}
+	second.WatchCh <- &MockResult{2, []byte{2}}
+	second.WatchCh <- &MockResult{3, []byte{3}}
+
+	group := &key.Group{Period: time.Second, GenesisTime: time.Now().Unix()}
+	p, _ := NewPrioritizingClient([]Client{first, second}, nil, group, log.DefaultLogger)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	ch := p.Watch(ctx)
+	for i := uint64(1); i <= 3; i++ {
+		r, ok := <-ch
+		if !ok {
+			t.Fatal("watch stopped early")
+		}
+		if r.Round() != i {
+			t.Fatalf("expected round %d, got %d", i, r.Round())
+		}
+	}
+}
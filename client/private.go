@@ -0,0 +1,116 @@
+package client
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/drand/drand/key"
+	"github.com/drand/drand/net"
+	"github.com/drand/drand/protobuf/drand"
+	"github.com/drand/kyber/encrypt/ecies"
+	"github.com/drand/kyber/util/random"
+)
+
+// eciesHash is the hash function of the ECIES encryption of private
+// randomness. It must match core.EciesHash.
+var eciesHash = sha256.New
+
+// privateRandomnessSize is the size of the private randomness of a node.
+const privateRandomnessSize = 32
+
+// GetPrivate requests private randomness from the nodes of the group over
+// gRPC and combines it into 32 bytes of private randomness. Each node receives
+// a fresh ephemeral key, encrypted to its identity key, to encrypt its
+// randomness. The output stays private as long as one of the nodes whose
+// randomness is combined is honest.
+//
+// All nodes are contacted concurrently and the randomness of the first n
+// nodes to answer is combined, or of all the nodes if n is not positive. It
+// fails if fewer than n nodes answer.
+func GetPrivate(ctx context.Context, group *key.Group, n int, client net.PublicClient) ([]byte, error) {
+	if group == nil || len(group.Nodes) == 0 {
+		return nil, errors.New("private randomness requires the nodes of the group")
+	}
+	if n > len(group.Nodes) {
+		return nil, fmt.Errorf("group only has %d nodes", len(group.Nodes))
+	}
+	if n <= 0 {
+		n = len(group.Nodes)
+	}
+	if client == nil {
+		client = net.NewGrpcClient()
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	type answer struct {
+		index      key.Index
+		randomness []byte
+		err        error
+	}
+	answers := make(chan answer, len(group.Nodes))
+	for _, node := range group.Nodes {
+		go func(node *key.Node) {
+			rand, err := getPrivate(ctx, node.Identity, client)
+			if err != nil {
+				err = fmt.Errorf("%s: %w", node.Address(), err)
+			}
+			answers <- answer{node.Index, rand, err}
+		}(node)
+	}
+
+	var valid []answer
+	var errs []error
+	for range group.Nodes {
+		a := <-answers
+		if a.err != nil {
+			errs = append(errs, a.err)
+			if len(group.Nodes)-len(errs) < n {
+				return nil, fmt.Errorf("only %d nodes can answer out of %d required, last error: %w", len(group.Nodes)-len(errs), n, a.err)
+			}
+			continue
+		}
+		valid = append(valid, a)
+		if len(valid) == n {
+			break
+		}
+	}
+
+	// combine in the order of the nodes, so the output does not depend on
+	// the order of the answers
+	sort.Slice(valid, func(i, j int) bool { return valid[i].index < valid[j].index })
+	h := sha256.New()
+	for _, a := range valid {
+		h.Write(a.randomness)
+	}
+	return h.Sum(nil), nil
+}
+
+// getPrivate requests private randomness from a node.
+func getPrivate(ctx context.Context, id *key.Identity, client net.PublicClient) ([]byte, error) {
+	ephScalar := key.KeyGroup.Scalar().Pick(random.New())
+	ephPoint := key.KeyGroup.Point().Mul(ephScalar, nil)
+	ephBuff, err := ephPoint.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	obj, err := ecies.Encrypt(key.KeyGroup, id.Key, ephBuff, eciesHash)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.PrivateRand(ctx, id, &drand.PrivateRandRequest{Request: obj})
+	if err != nil {
+		return nil, err
+	}
+	rand, err := ecies.Decrypt(key.KeyGroup, ephScalar, resp.GetResponse(), eciesHash)
+	if err != nil {
+		return nil, err
+	}
+	if len(rand) != privateRandomnessSize {
+		return nil, fmt.Errorf("expected %d bytes of randomness, got %d", privateRandomnessSize, len(rand))
+	}
+	return rand, nil
+}
@@ -0,0 +1,81 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"testing"
+
+	"github.com/drand/drand/key"
+	"github.com/drand/drand/net"
+	"github.com/drand/drand/protobuf/drand"
+	"github.com/drand/kyber"
+	"github.com/drand/kyber/encrypt/ecies"
+)
+
+// privateNodes answers private randomness requests as the nodes of a group
+// would, with a fixed randomness per node.
+type privateNodes struct {
+	net.PublicClient
+	keys       map[string]kyber.Scalar
+	randomness map[string][]byte
+}
+
+func (p *privateNodes) PrivateRand(ctx context.Context, peer net.Peer, in *drand.PrivateRandRequest) (*drand.PrivateRandResponse, error) {
+	priv, ok := p.keys[peer.Address()]
+	if !ok {
+		return nil, errors.New("node down")
+	}
+	msg, err := ecies.Decrypt(key.KeyGroup, priv, in.GetRequest(), eciesHash)
+	if err != nil {
+		return nil, err
+	}
+	clientKey := key.KeyGroup.Point()
+	if err := clientKey.UnmarshalBinary(msg); err != nil {
+		return nil, err
+	}
+	obj, err := ecies.Encrypt(key.KeyGroup, clientKey, p.randomness[peer.Address()], eciesHash)
+	return &drand.PrivateRandResponse{Response: obj}, err
+}
+
+func TestGetPrivate(t *testing.T) {
+	nodes := &privateNodes{keys: make(map[string]kyber.Scalar), randomness: make(map[string][]byte)}
+	group := new(key.Group)
+	for i, addr := range []string{"127.0.0.1:1", "127.0.0.1:2", "127.0.0.1:3"} {
+		pair := key.NewKeyPair(addr)
+		group.Nodes = append(group.Nodes, &key.Node{Identity: pair.Public, Index: key.Index(i)})
+		nodes.keys[addr] = pair.Key
+		nodes.randomness[addr] = bytes.Repeat([]byte{byte(i)}, 32)
+	}
+
+	rand, err := GetPrivate(context.Background(), group, 0, nodes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := sha256.New()
+	for i := 0; i < 3; i++ {
+		expected.Write(bytes.Repeat([]byte{byte(i)}, 32))
+	}
+	if !bytes.Equal(rand, expected.Sum(nil)) {
+		t.Fatal("randomness of all nodes should be combined")
+	}
+
+	// a node is down
+	delete(nodes.keys, "127.0.0.1:2")
+	if rand, err = GetPrivate(context.Background(), group, 2, nodes); err != nil {
+		t.Fatal(err)
+	}
+	expected.Reset()
+	expected.Write(bytes.Repeat([]byte{0}, 32))
+	expected.Write(bytes.Repeat([]byte{2}, 32))
+	if !bytes.Equal(rand, expected.Sum(nil)) {
+		t.Fatal("randomness of the answering nodes should be combined")
+	}
+	if _, err := GetPrivate(context.Background(), group, 3, nodes); err == nil {
+		t.Fatal("private randomness should fail without enough nodes")
+	}
+	if _, err := GetPrivate(context.Background(), group, 4, nodes); err == nil {
+		t.Fatal("more nodes than in the group should fail")
+	}
+}
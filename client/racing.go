@@ -0,0 +1,246 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/drand/drand/beacon"
+	"github.com/drand/drand/key"
+	"github.com/drand/drand/log"
+)
+
+// scoreAlpha is the weight of the last request in the exponentially decayed
+// averages of the latency and error rate of an endpoint.
+const scoreAlpha = 0.2
+
+// errorPenalty is the latency added to the score of an endpoint whose requests
+// all fail, in proportion to its error rate.
+const errorPenalty = 5 * time.Second
+
+// EndpointScore reports the health of an endpoint of a racing client.
+type EndpointScore struct {
+	// Endpoint identifies the sub-client, e.g. the URL of an HTTP relay.
+	Endpoint string
+	// Latency is the decayed average latency of the requests.
+	Latency time.Duration
+	// ErrorRate is the decayed average rate of failed requests, between 0
+	// and 1.
+	ErrorRate float64
+	// Requests is the number of requests sent to the endpoint.
+	Requests uint64
+	// Score is the latency penalised by the error rate, used to rank the
+	// endpoints. Lower is better.
+	Score time.Duration
+}
+
+// endpoint keeps the score of a sub-client.
+type endpoint struct {
+	client    Client
+	name      string
+	latency   float64
+	errorRate float64
+	requests  uint64
+}
+
+// score returns the latency of the endpoint penalised by its error rate.
+func (e *endpoint) score() float64 {
+	return e.latency + e.errorRate*float64(errorPenalty)
+}
+
+// RacingClient is a meta client that sends each request concurrently to the
+// best scoring sub-clients, returns the first answer and cancels the other
+// requests. Each sub-client is scored by the decayed average of its latency
+// and error rate.
+type RacingClient struct {
+	group *key.Group
+	k     int
+	log   log.Logger
+
+	lk        sync.Mutex
+	endpoints []*endpoint
+}
+
+// NewRacingClient creates a racing client sending each request to the k best
+// scoring clients at a time. Sub-clients are expected to verify the
+// randomness they return.
+func NewRacingClient(clients []Client, k int, group *key.Group, l log.Logger) (*RacingClient, error) {
+	if len(clients) == 0 {
+		return nil, errors.New("No clients to race")
+	}
+	if group == nil {
+		return nil, errors.New("racing client requires the group")
+	}
+	if k <= 0 {
+		return nil, errors.New("racing client must send requests to at least one client")
+	}
+	endpoints := make([]*endpoint, len(clients))
+	for i, c := range clients {
+		endpoints[i] = &endpoint{client: c, name: fmt.Sprint(c)}
+	}
+	return &RacingClient{group: group, k: k, log: l, endpoints: endpoints}, nil
+}
+
+// ranked returns the endpoints ordered from the best score to the worst.
+func (r *RacingClient) ranked() []*endpoint {
+	r.lk.Lock()
+	defer r.lk.Unlock()
+	sort.SliceStable(r.endpoints, func(i, j int) bool {
+		return r.endpoints[i].score() < r.endpoints[j].score()
+	})
+	return append([]*endpoint{}, r.endpoints...)
+}
+
+// record updates the score of the endpoint after a request. A request
+// cancelled because another endpoint answered first only gives a lower
+// bound of the latency, so the current estimate is kept if it is higher, and
+// it doesn't count as a failure.
+func (r *RacingClient) record(e *endpoint, latency time.Duration, failed, cancelled bool) {
+	r.lk.Lock()
+	defer r.lk.Unlock()
+	if cancelled && e.requests > 0 && float64(latency) < e.latency {
+		latency = time.Duration(e.latency)
+	}
+	failure := 0.0
+	if failed && !cancelled {
+		failure = 1
+	}
+	if e.requests == 0 {
+		e.latency = float64(latency)
+		e.errorRate = failure
+	} else {
+		e.latency = scoreAlpha*float64(latency) + (1-scoreAlpha)*e.latency
+		e.errorRate = scoreAlpha*failure + (1-scoreAlpha)*e.errorRate
+	}
+	e.requests++
+}
+
+// Scores returns the score of every endpoint, from the best to the worst.
+func (r *RacingClient) Scores() []EndpointScore {
+	ranked := r.ranked()
+	r.lk.Lock()
+	defer r.lk.Unlock()
+	scores := make([]EndpointScore, len(ranked))
+	for i, e := range ranked {
+		scores[i] = EndpointScore{
+			Endpoint:  e.name,
+			Latency:   time.Duration(e.latency),
+			ErrorRate: e.errorRate,
+			Requests:  e.requests,
+			Score:     time.Duration(e.score()),
+		}
+	}
+	return scores
+}
+
+// race sends the request to the best k endpoints, and to the next k ones if
+// they all fail, until an endpoint answers.
+func (r *RacingClient) race(ctx context.Context, call func(context.Context, Client) (interface{}, error)) (interface{}, error) {
+	ranked := r.ranked()
+	var err error
+	for start := 0; start < len(ranked); start += r.k {
+		end := start + r.k
+		if end > len(ranked) {
+			end = len(ranked)
+		}
+		var res interface{}
+		res, err = r.raceBatch(ctx, ranked[start:end], call)
+		if err == nil {
+			return res, nil
+		}
+		// context deadline hit or no round at that time
+		if ctx.Err() != nil || err == beacon.ErrBeforeGenesis || err == beacon.ErrFutureTime {
+			return nil, err
+		}
+	}
+	return nil, err
+}
+
+// raceBatch sends the request concurrently to the endpoints and returns the
+// first answer, or the last error if they all fail.
+func (r *RacingClient) raceBatch(ctx context.Context, batch []*endpoint, call func(context.Context, Client) (interface{}, error)) (interface{}, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type answer struct {
+		res interface{}
+		err error
+	}
+	// latencies are measured from the start of the batch, so the requests
+	// cancelled by the race are recorded slower than the winner
+	start := time.Now()
+	answers := make(chan answer, len(batch))
+	for _, e := range batch {
+		go func(e *endpoint) {
+			res, err := call(ctx, e.client)
+			r.record(e, time.Since(start), err != nil, err != nil && ctx.Err() != nil)
+			answers <- answer{res, err}
+		}(e)
+	}
+	var err error
+	for range batch {
+		a := <-answers
+		if a.err == nil {
+			return a.res, nil
+		}
+		r.log.Debug("racing_client", "request failed", "err", a.err)
+		err = a.err
+	}
+	return nil, err
+}
+
+// Get returns a the randomness at `round` or an error.
+func (r *RacingClient) Get(ctx context.Context, round uint64) (Result, error) {
+	res, err := r.race(ctx, func(ctx context.Context, c Client) (interface{}, error) {
+		return c.Get(ctx, round)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.(Result), nil
+}
+
+// GetAt returns the randomness of the round that was current at the given time.
+func (r *RacingClient) GetAt(ctx context.Context, t time.Time) (Result, error) {
+	res, err := r.race(ctx, func(ctx context.Context, c Client) (interface{}, error) {
+		return c.GetAt(ctx, t)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.(Result), nil
+}
+
+// GetRange implements the RangeClient interface.
+func (r *RacingClient) GetRange(ctx context.Context, from, to uint64) ([]Result, error) {
+	res, err := r.race(ctx, func(ctx context.Context, c Client) (interface{}, error) {
+		return GetRange(ctx, c, from, to)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.([]Result), nil
+}
+
+// Watch returns new randomness as it becomes available. It follows the watch
+// of the best scoring sub-client, e.g. its native stream, and fails over to
+// the next ones if it ends or stalls.
+func (r *RacingClient) Watch(ctx context.Context) <-chan Result {
+	return failoverWatcher(ctx, func() []Client {
+		ranked := r.ranked()
+		clients := make([]Client, len(ranked))
+		for i, e := range ranked {
+			clients[i] = e.client
+		}
+		return clients
+	}, r.group, r.log)
+}
+
+// RoundAt will return the most recent round of randomness that will be available
+// at time for the current client.
+func (r *RacingClient) RoundAt(time time.Time) uint64 {
+	return beacon.CurrentRound(time.Unix(), r.group.Period, r.group.GenesisTime)
+}
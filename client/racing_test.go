@@ -0,0 +1,162 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/drand/drand/key"
+	"github.com/drand/drand/log"
+)
+
+// delayedClient answers Get with its round after a delay, or fails.
+type delayedClient struct {
+	MockClient
+	name  string
+	delay time.Duration
+	round uint64
+	fail  bool
+}
+
+func (d *delayedClient) Get(ctx context.Context, round uint64) (Result, error) {
+	select {
+	case <-time.After(d.delay):
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	if d.fail {
+		return nil, errors.New("failed")
+	}
+	return &MockResult{d.round, []byte{byte(d.round)}}, nil
+}
+
+func (d *delayedClient) String() string {
+	return d.name
+}
+
+func TestRacingGet(t *testing.T) {
+	group := key.NewGroup([]*key.Identity{}, 1, time.Now().Unix(), time.Second)
+	slow := &delayedClient{name: "slow", delay: 500 * time.Millisecond, round: 1}
+	fast := &delayedClient{name: "fast", delay: 10 * time.Millisecond, round: 2}
+	r, err := NewRacingClient([]Client{slow, fast}, 2, group, log.DefaultLogger)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Now()
+	res, err := r.Get(context.Background(), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Round() != 2 || time.Since(start) >= slow.delay {
+		t.Fatal("expected the answer of the fastest client")
+	}
+	// wait for the cancelled request to be recorded
+	time.Sleep(50 * time.Millisecond)
+	scores := r.Scores()
+	if scores[0].Endpoint != "fast" || scores[1].Endpoint != "slow" {
+		t.Fatalf("fastest client should be ranked first: %+v", scores)
+	}
+	for _, score := range scores {
+		if score.ErrorRate != 0 || score.Requests != 1 {
+			t.Fatalf("cancelled request should not count as a failure: %+v", score)
+		}
+	}
+
+	// a request cancelled by the race doesn't lower the latency estimate
+	slowLatency := scores[1].Latency
+	slow.delay, fast.delay = 10*time.Millisecond, 5*time.Millisecond
+	if _, err := r.Get(context.Background(), 0); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(50 * time.Millisecond)
+	for _, score := range r.Scores() {
+		if score.Endpoint == "slow" && score.Latency < slowLatency {
+			t.Fatalf("cancelled request lowered the latency estimate: %v < %v", score.Latency, slowLatency)
+		}
+	}
+}
+
+func TestRacingRanking(t *testing.T) {
+	group := key.NewGroup([]*key.Identity{}, 1, time.Now().Unix(), time.Second)
+	slow := &delayedClient{name: "slow", delay: 100 * time.Millisecond, round: 1}
+	fast := &delayedClient{name: "fast", delay: 10 * time.Millisecond, round: 2}
+	r, err := NewRacingClient([]Client{slow, fast}, 1, group, log.DefaultLogger)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the slow client is asked first, then the unknown one, then the fastest
+	for _, expected := range []uint64{1, 2, 2} {
+		res, err := r.Get(context.Background(), 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res.Round() != expected {
+			t.Fatalf("expected round %d, got %d", expected, res.Round())
+		}
+	}
+	scores := r.Scores()
+	if scores[0].Endpoint != "fast" || scores[1].Endpoint != "slow" {
+		t.Fatalf("fastest client should be ranked first: %+v", scores)
+	}
+}
+
+func TestRacingFailover(t *testing.T) {
+	group := key.NewGroup([]*key.Identity{}, 1, time.Now().Unix(), time.Second)
+	failing := &delayedClient{name: "failing", fail: true}
+	ok := &delayedClient{name: "ok", delay: 10 * time.Millisecond, round: 3}
+	r, err := NewRacingClient([]Client{failing, ok}, 1, group, log.DefaultLogger)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := r.Get(context.Background(), 0)
+	if err != nil {
+		t.Fatal("should not error even when one client does")
+	}
+	if res.Round() != 3 {
+		t.Fatal("expected the answer of the working client")
+	}
+	scores := r.Scores()
+	if scores[0].Endpoint != "ok" || scores[1].ErrorRate != 1 {
+		t.Fatalf("failing client should be ranked last: %+v", scores)
+	}
+
+	ok.fail = true
+	if _, err := r.Get(context.Background(), 0); err == nil {
+		t.Fatal("should error when all clients do")
+	}
+}
+
+func TestRacingWatch(t *testing.T) {
+	group := key.NewGroup([]*key.Identity{}, 1, time.Now().Unix(), time.Second)
+	failing := &delayedClient{name: "failing", fail: true}
+	ok := &delayedClient{name: "ok", delay: 10 * time.Millisecond, round: 1}
+	failing.WatchCh = make(chan Result, 1)
+	failing.WatchCh <- &MockResult{2, []byte{2}}
+	// the stream of the best client stalls after the first round
+	ok.WatchCh = make(chan Result, 1)
+	ok.WatchCh <- &MockResult{1, []byte{1}}
+	r, err := NewRacingClient([]Client{failing, ok}, 1, group, log.DefaultLogger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.Get(context.Background(), 0); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	ch := r.Watch(ctx)
+	for i := uint64(1); i <= 2; i++ {
+		res, ok := <-ch
+		if !ok {
+			t.Fatal("watch stopped early")
+		}
+		if res.Round() != i {
+			t.Fatalf("expected round %d from the best client first, got %d", i, res.Round())
+		}
+	}
+}
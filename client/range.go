@@ -0,0 +1,71 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/drand/kyber"
+)
+
+// maxRangePrealloc bounds the capacity preallocated for the results of a
+// range, since the bounds of the range are given by the caller.
+const maxRangePrealloc = 1000
+
+// checkRange returns an error if the range from `from` to `to` is empty or
+// starts at round 0.
+func checkRange(from, to uint64) error {
+	if from == 0 || to < from {
+		return fmt.Errorf("invalid range from %d to %d", from, to)
+	}
+	return nil
+}
+
+// rangeResults returns an empty slice to collect the results of the range.
+func rangeResults(from, to uint64) []Result {
+	n := to - from + 1
+	if n > maxRangePrealloc {
+		n = maxRangePrealloc
+	}
+	return make([]Result, 0, n)
+}
+
+// GetRange returns the randomness of rounds from `from` to `to` included, in
+// order. It uses a single range request if the client implements RangeClient,
+// and fetches the rounds one by one otherwise.
+func GetRange(ctx context.Context, c Client, from, to uint64) ([]Result, error) {
+	if err := checkRange(from, to); err != nil {
+		return nil, err
+	}
+	if rc, ok := c.(RangeClient); ok {
+		return rc.GetRange(ctx, from, to)
+	}
+	results := rangeResults(from, to)
+	for round := from; round <= to; round++ {
+		r, err := c.Get(ctx, round)
+		if err != nil {
+			return results, err
+		}
+		results = append(results, r)
+	}
+	return results, nil
+}
+
+// verifySegment verifies in one pass that the beacons form a valid chain
+// segment starting at round `from`: rounds are consecutive, each beacon builds
+// on the previous one and carries a valid signature. The first beacon must
+// build on prev if it is not nil. It returns the last beacon of the segment.
+func verifySegment(pub kyber.Point, from uint64, prev *RandomData, segment []*RandomData) (*RandomData, error) {
+	for i, r := range segment {
+		if r.Rnd != from+uint64(i) {
+			return prev, &ErrRoundMismatch{Requested: from + uint64(i), Received: r.Rnd}
+		}
+		if err := verifyLinks(r, prev, nil); err != nil {
+			return prev, err
+		}
+		if err := verifySignature(pub, r); err != nil {
+			return prev, err
+		}
+		prev = r
+	}
+	return prev, nil
+}
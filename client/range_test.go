@@ -0,0 +1,138 @@
+package client
+
+import (
+	"context"
+	"io/ioutil"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"testing"
+
+	json "github.com/nikkolasg/hexjson"
+)
+
+// withRangeServer serves the given chain over the range endpoint by pages of
+// two beacons. It returns the server and a counter of requests.
+func withRangeServer(t *testing.T, chain []*RandomData) (*httptest.Server, *int) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		from, err := strconv.ParseUint(r.URL.Query().Get("from"), 10, 64)
+		if err != nil || from == 0 {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		page := make([]*RandomData, 0)
+		for _, r := range chain {
+			if r.Rnd >= from && len(page) < 2 {
+				page = append(page, r)
+			}
+		}
+		json.NewEncoder(w).Encode(page)
+	}))
+	return server, &requests
+}
+
+func TestHTTPGetRange(t *testing.T) {
+	group, chain := fakeChain(t, 6)
+	server, requests := withRangeServer(t, chain)
+	defer server.Close()
+
+	c, err := NewHTTPClientWithGroup(server.URL, group, &http.Client{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	results, err := GetRange(context.Background(), c, 2, 6)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 5 || *requests != 3 {
+		t.Fatalf("expected 5 results in 3 requests, got %d in %d", len(results), *requests)
+	}
+	for i, r := range results {
+		if r.Round() != uint64(i+2) {
+			t.Fatalf("expected round %d, got %d", i+2, r.Round())
+		}
+	}
+
+	// missing rounds
+	if _, err := GetRange(context.Background(), c, 5, 8); err == nil {
+		t.Fatal("range after the last round should fail.")
+	}
+
+	// broken chain
+	forked := *chain[3]
+	forked.PreviousSignature = chain[1].Signature
+	chain[3] = &forked
+	if _, err := GetRange(context.Background(), c, 1, 6); err == nil {
+		t.Fatal("range with a fork should fail.")
+	}
+}
+
+func TestGetRangeFallback(t *testing.T) {
+	c := MockClientWithResults(3, 6)
+	results, err := GetRange(context.Background(), c, 3, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 3 || results[2].Round() != 5 {
+		t.Fatal("expected rounds to be fetched one by one.")
+	}
+
+	group, chain := fakeChain(t, 4)
+	server, requests := withRangeServer(t, chain)
+	defer server.Close()
+	hc, _ := NewHTTPClientWithGroup(server.URL, group, &http.Client{})
+	cc, _ := NewCachingClient(hc, 10, nil)
+	c = newWatchAggregator(cc, nil)
+	if _, err := GetRange(context.Background(), c, 1, 4); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := GetRange(context.Background(), c, 2, 3); err != nil {
+		t.Fatal(err)
+	}
+	if *requests != 2 {
+		t.Fatalf("expected cached range to be served without requests, got %d requests", *requests)
+	}
+}
+
+func TestGetRangeInvalid(t *testing.T) {
+	dir, err := ioutil.TempDir("", "drand-client-range")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	group, chain := fakeChain(t, 4)
+	server, _ := withRangeServer(t, chain)
+	defer server.Close()
+	hc, err := NewHTTPClientWithGroup(server.URL, group, &http.Client{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cc, err := NewCachingClient(hc, 10, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dc, err := NewDiskCachingClient(hc, dir, group, 10, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dc.Close()
+
+	for _, c := range []RangeClient{hc.(RangeClient), cc.(RangeClient), dc} {
+		if _, err := c.GetRange(context.Background(), 5, 2); err == nil {
+			t.Fatal("range ending before its start should fail")
+		}
+		if _, err := c.GetRange(context.Background(), 0, 2); err == nil {
+			t.Fatal("range starting at round 0 should fail")
+		}
+		// the bound is not trusted to allocate the results
+		results, err := c.GetRange(context.Background(), 3, math.MaxUint64)
+		if err == nil || len(results) != 2 {
+			t.Fatalf("unbounded range should fail after the last round, got %d results and %v", len(results), err)
+		}
+	}
+}
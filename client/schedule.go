@@ -0,0 +1,198 @@
+package client
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/drand/drand/log"
+)
+
+// maxRoundTimeDoublings bounds the search of the start of a round to about a
+// million years from now.
+const maxRoundTimeDoublings = 45
+
+// roundTime returns the time at which the round starts. RoundAt only tells the
+// round of a time, so the start of the round is found by bisection over the
+// seconds following now. It returns now if the round has already started, or
+// if it never starts according to RoundAt.
+func roundTime(c Client, round uint64) time.Time {
+	now := time.Now().Unix()
+	if c.RoundAt(time.Unix(now, 0)) >= round {
+		return time.Unix(now, 0)
+	}
+	// find an upper bound, then the first second at which the round is current
+	lo, hi := now, now+1
+	for i := 0; c.RoundAt(time.Unix(hi, 0)) < round; i++ {
+		if i == maxRoundTimeDoublings {
+			return time.Unix(now, 0)
+		}
+		lo, hi = hi, now+2*(hi-now)
+	}
+	for hi-lo > 1 {
+		mid := lo + (hi-lo)/2
+		if c.RoundAt(time.Unix(mid, 0)) >= round {
+			hi = mid
+		} else {
+			lo = mid
+		}
+	}
+	return time.Unix(hi, 0)
+}
+
+// WaitFor blocks until the randomness of the round is produced and returns it.
+// It waits until the expected time of the round plus some slack, then fetches
+// the round, retrying until it succeeds or the context is done. Rounds that
+// were already produced are returned immediately.
+func WaitFor(ctx context.Context, c Client, round uint64) (Result, error) {
+	start := roundTime(c, round)
+	period := roundTime(c, round+1).Sub(start)
+	effectiveSlack := slack
+	if period > 0 && period < effectiveSlack {
+		effectiveSlack = period
+	}
+	if wait := time.Until(start); wait > 0 {
+		select {
+		case <-time.After(wait + effectiveSlack):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	retry := RetryPolicy{MinBackoff: effectiveSlack, MaxBackoff: period}
+	if retry.MaxBackoff < retry.MinBackoff {
+		retry.MaxBackoff = retry.MinBackoff
+	}
+	for i := 0; ; i++ {
+		r, err := c.Get(ctx, round)
+		if err == nil {
+			return r, nil
+		}
+		select {
+		case <-time.After(retry.backoff(i)):
+		case <-ctx.Done():
+			return nil, err
+		}
+	}
+}
+
+// At blocks until the randomness of the round current at the given time is
+// produced and returns it.
+func At(ctx context.Context, c Client, t time.Time) (Result, error) {
+	return WaitFor(ctx, c, c.RoundAt(t))
+}
+
+// Scheduler calls the callbacks registered for future rounds when their
+// randomness is produced. It follows new rounds with Watch, and fetches the
+// rounds that were missed, e.g. rounds registered after being produced.
+type Scheduler struct {
+	client Client
+	log    log.Logger
+
+	lk      sync.Mutex
+	pending map[uint64][]func(Result)
+	wake    chan struct{}
+}
+
+// NewScheduler returns a scheduler of callbacks on the rounds of the client.
+// It does nothing until Run is called.
+func NewScheduler(c Client, l log.Logger) *Scheduler {
+	return &Scheduler{
+		client:  c,
+		log:     l,
+		pending: make(map[uint64][]func(Result)),
+		wake:    make(chan struct{}, 1),
+	}
+}
+
+// Schedule registers the callback to be called with the randomness of the
+// round.
+func (s *Scheduler) Schedule(round uint64, cb func(Result)) {
+	s.lk.Lock()
+	s.pending[round] = append(s.pending[round], cb)
+	s.lk.Unlock()
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// ScheduleAt registers the callback to be called with the randomness of the
+// round current at the given time.
+func (s *Scheduler) ScheduleAt(t time.Time, cb func(Result)) {
+	s.Schedule(s.client.RoundAt(t), cb)
+}
+
+// Pending returns the number of rounds with callbacks not yet called.
+func (s *Scheduler) Pending() int {
+	s.lk.Lock()
+	defer s.lk.Unlock()
+	return len(s.pending)
+}
+
+// Run calls the callbacks until the context is done. Callbacks are called one
+// at a time from the goroutine of Run, in the order of the rounds.
+func (s *Scheduler) Run(ctx context.Context) {
+	var latest uint64
+	for {
+		ch := s.client.Watch(ctx)
+	watch:
+		for {
+			select {
+			case r, ok := <-ch:
+				if !ok {
+					break watch
+				}
+				if r.Round() > latest {
+					latest = r.Round()
+				}
+				s.fire(ctx, latest, r)
+			case <-s.wake:
+				s.fire(ctx, latest, nil)
+			case <-ctx.Done():
+				return
+			}
+		}
+		s.log.Warn("scheduler", "watch interrupted", "latest", latest)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(slack):
+		}
+	}
+}
+
+// fire calls the callbacks of the rounds up to latest. The randomness of the
+// rounds other than the result r, if any, is fetched from the client. Rounds
+// that can't be fetched stay pending until the next round.
+func (s *Scheduler) fire(ctx context.Context, latest uint64, r Result) {
+	s.lk.Lock()
+	var due []uint64
+	for round := range s.pending {
+		if round <= latest {
+			due = append(due, round)
+		}
+	}
+	s.lk.Unlock()
+	sort.Slice(due, func(i, j int) bool { return due[i] < due[j] })
+
+	for _, round := range due {
+		res := r
+		if res == nil || res.Round() != round {
+			var err error
+			res, err = s.client.Get(ctx, round)
+			if err != nil {
+				s.log.Warn("scheduler", "failed to fetch round", "round", round, "err", err)
+				continue
+			}
+		}
+		s.lk.Lock()
+		cbs := s.pending[round]
+		delete(s.pending, round)
+		s.lk.Unlock()
+		for _, cb := range cbs {
+			cb(res)
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/drand/drand/beacon"
+	"github.com/drand/drand/key"
+	"github.com/drand/drand/log"
+)
+
+// timedClient serves the rounds of the chain once their time has come.
+type timedClient struct {
+	group   *key.Group
+	chain   []*RandomData
+	watchCh chan Result
+}
+
+func (c *timedClient) Get(ctx context.Context, round uint64) (Result, error) {
+	if round == 0 {
+		round = c.RoundAt(time.Now())
+	}
+	if round > c.RoundAt(time.Now()) || round > uint64(len(c.chain)) {
+		return nil, fmt.Errorf("round %d not produced yet", round)
+	}
+	return c.chain[round-1], nil
+}
+
+func (c *timedClient) GetAt(ctx context.Context, t time.Time) (Result, error) {
+	return c.Get(ctx, c.RoundAt(t))
+}
+
+func (c *timedClient) Watch(ctx context.Context) <-chan Result {
+	return c.watchCh
+}
+
+func (c *timedClient) RoundAt(t time.Time) uint64 {
+	return beacon.CurrentRound(t.Unix(), c.group.Period, c.group.GenesisTime)
+}
+
+func TestRoundTime(t *testing.T) {
+	group, chain := fakeChain(t, 1)
+	group.GenesisTime = time.Now().Unix() - 10
+	c := &timedClient{group: group, chain: chain}
+	for round := uint64(2); round < 10000; round *= 3 {
+		expected := beacon.TimeOfRound(group.Period, group.GenesisTime, round)
+		if now := time.Now().Unix(); expected < now {
+			expected = now
+		}
+		if at := roundTime(c, round).Unix(); at != expected {
+			t.Fatalf("round %d: expected time %d, got %d", round, expected, at)
+		}
+	}
+	// a client whose rounds never advance must not loop forever
+	if at := roundTime(new(MockClient), 10); time.Since(at) > time.Second {
+		t.Fatalf("unexpected time %v", at)
+	}
+}
+
+func TestWaitFor(t *testing.T) {
+	group, chain := fakeChain(t, 3)
+	group.Period = time.Second
+	c := &timedClient{group: group, chain: chain}
+
+	start := time.Now()
+	r, err := WaitFor(context.Background(), c, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Round() != 2 {
+		t.Fatalf("expected round 2, got %d", r.Round())
+	}
+	if time.Since(start) > 4*time.Second {
+		t.Fatal("waited too long for the round")
+	}
+
+	// produced rounds are returned immediately
+	start = time.Now()
+	if r, err = At(context.Background(), c, time.Unix(group.GenesisTime, 0)); err != nil || r.Round() != 1 {
+		t.Fatalf("expected round 1, got %v: %v", r, err)
+	}
+	if time.Since(start) > 100*time.Millisecond {
+		t.Fatal("produced round should not wait")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if _, err := WaitFor(ctx, c, 100); err == nil {
+		t.Fatal("wait should stop with the context")
+	}
+}
+
+func TestScheduler(t *testing.T) {
+	group, chain := fakeChain(t, 5)
+	group.GenesisTime = time.Now().Unix() - 10*60
+	c := &timedClient{group: group, chain: chain, watchCh: make(chan Result)}
+	s := NewScheduler(c, log.DefaultLogger)
+
+	fired := make(chan Result, 10)
+	cb := func(r Result) { fired <- r }
+	s.Schedule(1, cb)
+	s.Schedule(4, cb)
+	s.Schedule(5, cb)
+	s.Schedule(5, cb)
+	s.Schedule(20, cb)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go s.Run(ctx)
+
+	// round 4 is received, round 1 was missed and is fetched
+	c.watchCh <- chain[3]
+	for _, expected := range []uint64{1, 4} {
+		select {
+		case r := <-fired:
+			if r.Round() != expected {
+				t.Fatalf("expected round %d, got %d", expected, r.Round())
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("round %d not fired", expected)
+		}
+	}
+
+	c.watchCh <- chain[4]
+	for i := 0; i < 2; i++ {
+		select {
+		case r := <-fired:
+			if r.Round() != 5 {
+				t.Fatalf("expected round 5, got %d", r.Round())
+			}
+		case <-time.After(time.Second):
+			t.Fatal("round 5 not fired")
+		}
+	}
+
+	// rounds registered after being produced fire right away
+	s.Schedule(2, cb)
+	select {
+	case r := <-fired:
+		if r.Round() != 2 {
+			t.Fatalf("expected round 2, got %d", r.Round())
+		}
+	case <-time.After(time.Second):
+		t.Fatal("round 2 not fired")
+	}
+	if s.Pending() != 1 {
+		t.Fatalf("expected round 20 to be pending, got %d rounds", s.Pending())
+	}
+}
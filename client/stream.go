@@ -0,0 +1,164 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	json "github.com/nikkolasg/hexjson"
+)
+
+// errStreamUnsupported is returned when the relay does not serve the stream
+// endpoint.
+var errStreamUnsupported = errors.New("relay does not support streaming")
+
+// errStreamStalled is returned when the stream of the relay sends no beacon
+// for streamStallPeriods periods.
+var errStreamStalled = errors.New("relay stream stalled")
+
+// streamStallPeriods is the number of periods without any beacon after which
+// the stream is considered stalled, and the relay is polled instead.
+const streamStallPeriods = 2
+
+// openStream opens the event stream of the relay, resuming after the round
+// last if it is not zero.
+func (h *httpClient) openStream(ctx context.Context, last uint64) (*http.Response, error) {
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s/public/stream", h.root), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	if last > 0 {
+		req.Header.Set("Last-Event-ID", strconv.FormatUint(last, 10))
+	}
+	resp, err := h.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if resp.StatusCode != http.StatusOK || mediaType != "text/event-stream" {
+		resp.Body.Close()
+		return nil, errStreamUnsupported
+	}
+	return resp, nil
+}
+
+// streamingWatcher sends on the channel the beacons received from the event
+// stream of the relay. It reconnects to the stream, resuming after the last
+// round received, until the context is done. It polls the relay instead if the
+// stream stalls or is no longer supported.
+func (h *httpClient) streamingWatcher(ctx context.Context, resp *http.Response, ch chan<- Result) {
+	defer close(ch)
+	var last uint64
+	for {
+		err := h.readStream(ctx, resp, &last, ch)
+		resp.Body.Close()
+		if ctx.Err() != nil {
+			return
+		}
+		if err == errStreamStalled {
+			h.l.Warn("http_client", "stream stalled, polling", "last", last)
+			h.pollAfter(ctx, last, ch)
+			return
+		}
+		h.l.Warn("http_client", "stream interrupted", "last", last, "err", err)
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(slack):
+			}
+			resp, err = h.openStream(ctx, last)
+			if err == nil {
+				break
+			}
+			if err == errStreamUnsupported {
+				h.l.Warn("http_client", "stream no longer supported, polling", "last", last)
+				h.pollAfter(ctx, last, ch)
+				return
+			}
+			h.l.Warn("http_client", "failed to reopen stream", "err", err)
+		}
+	}
+}
+
+// pollAfter sends on the channel the beacons following the round last,
+// polling the relay until the context is done.
+func (h *httpClient) pollAfter(ctx context.Context, last uint64, ch chan<- Result) {
+	for r := range pollingWatcher(ctx, h, h.group, h.l) {
+		if r.Round() <= last {
+			continue
+		}
+		select {
+		case ch <- r:
+			last = r.Round()
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// readStream parses the Server-Sent Events of the stream and sends the
+// verified beacons following the round last. It returns errStreamStalled if no
+// beacon is received for streamStallPeriods periods.
+func (h *httpClient) readStream(ctx context.Context, resp *http.Response, last *uint64, ch chan<- Result) error {
+	stallTimeout := streamStallPeriods*h.group.Period + slack
+	var stalled int32
+	stall := time.AfterFunc(stallTimeout, func() {
+		atomic.StoreInt32(&stalled, 1)
+		resp.Body.Close()
+	})
+	defer stall.Stop()
+
+	scanner := bufio.NewScanner(resp.Body)
+	var data []byte
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line != "" {
+			if strings.HasPrefix(line, "data:") {
+				data = append(data, strings.TrimPrefix(strings.TrimPrefix(line, "data:"), " ")...)
+			}
+			continue
+		}
+		// an empty line dispatches the event
+		if len(data) == 0 {
+			continue
+		}
+		rand := &RandomData{source: h.root}
+		err := json.Unmarshal(data, rand)
+		data = data[:0]
+		if err != nil {
+			return err
+		}
+		if rand.Rnd <= *last {
+			continue
+		}
+		if err := verifyResponse(h.group.PublicKey.Key(), 0, rand, h.strict); err != nil {
+			h.l.Warn("http_client", "failed to verify value", "round", rand.Rnd, "err", err)
+			continue
+		}
+		rand.setTime(h.group)
+		stall.Reset(stallTimeout)
+		select {
+		case ch <- rand:
+			*last = rand.Rnd
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	if atomic.LoadInt32(&stalled) == 1 {
+		return errStreamStalled
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	return errors.New("stream closed by the relay")
+}
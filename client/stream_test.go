@@ -0,0 +1,159 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	json "github.com/nikkolasg/hexjson"
+)
+
+func TestHTTPWatchStream(t *testing.T) {
+	group, chain := fakeChain(t, 5)
+	invalid := *chain[2]
+	invalid.Signature = chain[1].Signature
+
+	// the server closes the stream after a few events, the client must resume
+	// it after the last round received
+	var resumedLk sync.Mutex
+	var resumed []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/public/stream" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		var last uint64
+		if id := r.Header.Get("Last-Event-ID"); id != "" {
+			resumedLk.Lock()
+			resumed = append(resumed, id)
+			resumedLk.Unlock()
+			last, _ = strconv.ParseUint(id, 10, 64)
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		events := []*RandomData{&invalid}
+		for _, r := range chain {
+			if r.Rnd > last && len(events) < 3 {
+				events = append(events, r)
+			}
+		}
+		for _, e := range events {
+			data, _ := json.Marshal(e)
+			fmt.Fprintf(w, "id: %d\ndata: %s\n\n", e.Rnd, data)
+		}
+	}))
+	defer server.Close()
+
+	c, err := NewHTTPClientWithGroup(server.URL, group, &http.Client{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	results := c.Watch(ctx)
+	for i := 1; i <= len(chain); i++ {
+		r, ok := <-results
+		if !ok {
+			t.Fatal("watch stopped early")
+		}
+		if r.Round() != uint64(i) {
+			t.Fatalf("expected round %d, got %d", i, r.Round())
+		}
+	}
+	resumedLk.Lock()
+	defer resumedLk.Unlock()
+	if len(resumed) < 2 || resumed[0] != "2" || resumed[1] != "4" {
+		t.Fatalf("unexpected resumed streams: %v", resumed)
+	}
+	cancel()
+	for range results {
+	}
+}
+
+func TestClientWatchStreamMultipleEndpoints(t *testing.T) {
+	group, chain := fakeChain(t, 3)
+	// only the first relay streams, none of them serves the rounds on request
+	streaming := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/public/stream" || r.Header.Get("Last-Event-ID") != "" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		for _, e := range chain {
+			data, _ := json.Marshal(e)
+			fmt.Fprintf(w, "id: %d\ndata: %s\n\n", e.Rnd, data)
+		}
+	}))
+	defer streaming.Close()
+	other := httptest.NewServer(http.NotFoundHandler())
+	defer other.Close()
+
+	c, err := New(WithHTTPEndpoints([]string{streaming.URL, other.URL}), WithGroup(group), WithCacheSize(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	results := c.Watch(ctx)
+	for i := 1; i <= len(chain); i++ {
+		r, ok := <-results
+		if !ok {
+			t.Fatal("watch stopped early")
+		}
+		if r.Round() != uint64(i) {
+			t.Fatalf("expected round %d from the stream, got %d", i, r.Round())
+		}
+	}
+}
+
+func TestHTTPWatchStreamStalled(t *testing.T) {
+	group, chain := fakeChain(t, 20)
+	group.Period = time.Second
+
+	// the stream sends the first round and then nothing, the client must poll
+	// the relay for the following rounds
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/public/stream" {
+			w.Header().Set("Content-Type", "text/event-stream")
+			data, _ := json.Marshal(chain[0])
+			fmt.Fprintf(w, "id: %d\ndata: %s\n\n", chain[0].Rnd, data)
+			w.(http.Flusher).Flush()
+			<-r.Context().Done()
+			return
+		}
+		round, err := strconv.ParseUint(r.URL.Path[len("/public/"):], 10, 64)
+		if err != nil || round == 0 || round > uint64(len(chain)) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		data, _ := json.Marshal(chain[round-1])
+		w.Write(data)
+	}))
+	defer server.Close()
+
+	c, err := NewHTTPClientWithGroup(server.URL, group, &http.Client{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	results := c.Watch(ctx)
+	r, ok := <-results
+	if !ok || r.Round() != 1 {
+		t.Fatal("expected the first round from the stream")
+	}
+	r, ok = <-results
+	if !ok {
+		t.Fatal("watch stopped instead of polling the stalled relay")
+	}
+	if r.Round() <= 1 {
+		t.Fatalf("expected a round after 1, got %d", r.Round())
+	}
+	cancel()
+	for range results {
+	}
+}
@@ -0,0 +1,140 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// RetryPolicy configures how failed HTTP requests are retried. Requests are
+// retried on transport errors, such as timeouts, and on 5xx responses.
+type RetryPolicy struct {
+	// MaxRetries is the number of retries after the first attempt. Zero
+	// disables retries.
+	MaxRetries int
+	// MinBackoff is the delay before the first retry. It doubles after each
+	// retry, up to MaxBackoff. Delays are jittered.
+	MinBackoff time.Duration
+	// MaxBackoff bounds the delay between retries.
+	MaxBackoff time.Duration
+}
+
+// DefaultRetryPolicy is the retry policy of HTTP clients.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxRetries: 2,
+	MinBackoff: 100 * time.Millisecond,
+	MaxBackoff: 2 * time.Second,
+}
+
+// backoff returns the jittered delay before the given retry, starting at 0.
+func (p RetryPolicy) backoff(retry int) time.Duration {
+	d := p.MinBackoff
+	for i := 0; i < retry && d < p.MaxBackoff; i++ {
+		d *= 2
+	}
+	if d > p.MaxBackoff {
+		d = p.MaxBackoff
+	}
+	if d <= 0 {
+		return 0
+	}
+	// between half and the full delay
+	return d/2 + time.Duration(rand.Int63n(int64(d/2)+1))
+}
+
+// ErrCircuitOpen is returned without contacting an endpoint while its circuit
+// breaker is open, after too many consecutive failures.
+var ErrCircuitOpen = errors.New("client: circuit breaker open")
+
+// DefaultBreakerThreshold is the number of consecutive failed requests after
+// which the circuit breaker of an endpoint opens.
+var DefaultBreakerThreshold = 5
+
+// DefaultBreakerCooldown is the duration during which the circuit breaker of
+// an endpoint stays open.
+var DefaultBreakerCooldown = 30 * time.Second
+
+// circuitBreaker stops sending requests to an endpoint for a cooldown period
+// after a number of consecutive failures. After the cooldown, requests are
+// sent again and the first failure opens the breaker again.
+type circuitBreaker struct {
+	sync.Mutex
+	threshold int
+	cooldown  time.Duration
+	failures  int
+	openUntil time.Time
+}
+
+func newCircuitBreaker(threshold int, cooldown time.Duration) *circuitBreaker {
+	return &circuitBreaker{threshold: threshold, cooldown: cooldown}
+}
+
+// allow returns ErrCircuitOpen if requests must not be sent.
+func (b *circuitBreaker) allow() error {
+	b.Lock()
+	defer b.Unlock()
+	if time.Now().Before(b.openUntil) {
+		return ErrCircuitOpen
+	}
+	return nil
+}
+
+// record updates the breaker after a request.
+func (b *circuitBreaker) record(failed bool) {
+	b.Lock()
+	defer b.Unlock()
+	if !failed {
+		b.failures = 0
+		return
+	}
+	b.failures++
+	if b.threshold > 0 && b.failures >= b.threshold {
+		b.openUntil = time.Now().Add(b.cooldown)
+	}
+}
+
+// get sends a GET request to the endpoint bound to the context, retrying it
+// according to the retry policy. It returns the response only if its status is
+// 200 OK, and closes its body otherwise.
+func (h *httpClient) get(ctx context.Context, url string) (*http.Response, error) {
+	if err := h.breaker.allow(); err != nil {
+		return nil, fmt.Errorf("%s: %w", h.root, err)
+	}
+	for retry := 0; ; retry++ {
+		req, err := http.NewRequest("GET", url, nil)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := h.client.Do(req.WithContext(ctx))
+		if err == nil && resp.StatusCode < http.StatusInternalServerError {
+			// the endpoint is healthy even if it can't serve the request
+			h.breaker.record(false)
+			if resp.StatusCode != http.StatusOK {
+				resp.Body.Close()
+				return nil, fmt.Errorf("%s: %s", url, resp.Status)
+			}
+			return resp, nil
+		}
+		if err == nil {
+			resp.Body.Close()
+			err = fmt.Errorf("%s: %s", url, resp.Status)
+		}
+		if ctx.Err() != nil {
+			return nil, err
+		}
+		if retry >= h.retry.MaxRetries {
+			h.breaker.record(true)
+			return nil, err
+		}
+		h.l.Debug("http_client", "retrying request", "url", url, "err", err)
+		select {
+		case <-time.After(h.retry.backoff(retry)):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
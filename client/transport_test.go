@@ -0,0 +1,108 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	json "github.com/nikkolasg/hexjson"
+)
+
+// withFlakyServer serves the chain, failing the first requests with a 500
+// status. It returns the server and a counter of requests.
+func withFlakyServer(t *testing.T, chain []*RandomData, failures int32, delay time.Duration) (*httptest.Server, *int32) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) <= failures {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		time.Sleep(delay)
+		round, _ := strconv.ParseUint(strings.TrimPrefix(r.URL.Path, "/public/"), 10, 64)
+		if round == 0 || round > uint64(len(chain)) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		json.NewEncoder(w).Encode(chain[round-1])
+	}))
+	return server, &requests
+}
+
+func TestHTTPRetries(t *testing.T) {
+	group, chain := fakeChain(t, 3)
+	server, requests := withFlakyServer(t, chain, 2, 0)
+	defer server.Close()
+
+	c, err := New(WithHTTPEndpoints([]string{server.URL}), WithGroup(group), WithCacheSize(0),
+		WithRetryPolicy(RetryPolicy{MaxRetries: 2, MinBackoff: time.Millisecond, MaxBackoff: 10 * time.Millisecond}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := c.Get(context.Background(), 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if atomic.LoadInt32(requests) != 3 {
+		t.Fatalf("expected 3 requests, got %d", atomic.LoadInt32(requests))
+	}
+	if r.(*RandomData).Source() != server.URL {
+		t.Fatalf("unexpected source %q", r.(*RandomData).Source())
+	}
+
+	// not found is not retried
+	if _, err := c.Get(context.Background(), 10); err == nil {
+		t.Fatal("missing round should fail")
+	}
+	if atomic.LoadInt32(requests) != 4 {
+		t.Fatalf("expected 4 requests, got %d", atomic.LoadInt32(requests))
+	}
+}
+
+func TestHTTPContext(t *testing.T) {
+	group, chain := fakeChain(t, 3)
+	server, _ := withFlakyServer(t, chain, 0, time.Second)
+	defer server.Close()
+
+	c, err := NewHTTPClientWithGroup(server.URL, group, &http.Client{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if _, err := c.Get(ctx, 1); err == nil {
+		t.Fatal("request should be cancelled")
+	}
+	if time.Since(start) > 500*time.Millisecond {
+		t.Fatal("request should stop at the deadline")
+	}
+}
+
+func TestHTTPCircuitBreaker(t *testing.T) {
+	group, chain := fakeChain(t, 3)
+	server, requests := withFlakyServer(t, chain, 100, 0)
+	defer server.Close()
+
+	c, err := New(WithHTTPEndpoints([]string{server.URL}), WithGroup(group), WithCacheSize(0),
+		WithRetryPolicy(RetryPolicy{}), WithCircuitBreaker(2, time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := c.Get(context.Background(), 1); err == nil || errors.Is(err, ErrCircuitOpen) {
+			t.Fatalf("expected a server error, got %v", err)
+		}
+	}
+	if _, err := c.Get(context.Background(), 1); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected the circuit to be open, got %v", err)
+	}
+	if atomic.LoadInt32(requests) != 2 {
+		t.Fatalf("no request should be sent while the circuit is open, got %d", atomic.LoadInt32(requests))
+	}
+}
@@ -2,6 +2,7 @@ package client
 
 import (
 	"context"
+	"io"
 	"sync"
 	"time"
 
@@ -71,6 +72,70 @@ func pollingWatcher(ctx context.Context, client Client, group *key.Group, log lo
 	return ch
 }
 
+// failoverWatcher sends the new randomness of the watch of the first client,
+// e.g. its native stream, and watches the next client when the watch ends or
+// stalls for streamStallPeriods periods. The clients are asked for their
+// current order each time all of them have been watched. It resumes after the
+// last round sent, until the context is done.
+func failoverWatcher(ctx context.Context, clients func() []Client, group *key.Group, log log.Logger) <-chan Result {
+	ch := make(chan Result, 1)
+	stallTimeout := streamStallPeriods*group.Period + slack
+	go func() {
+		defer close(ch)
+		var last uint64
+		for {
+			for _, c := range clients() {
+				if !forwardWatch(ctx, c, stallTimeout, &last, ch) {
+					return
+				}
+				log.Warn("failover_watcher", "watch ended, watching next client", "last", last)
+				select {
+				case <-ctx.Done():
+					return
+				case <-time.After(slack):
+				}
+			}
+		}
+	}()
+	return ch
+}
+
+// forwardWatch sends on the channel the results of the watch of the client
+// following the round last, until the watch ends or sends nothing for the
+// stall timeout. It returns false if the context is done.
+func forwardWatch(ctx context.Context, c Client, stallTimeout time.Duration, last *uint64, ch chan<- Result) bool {
+	wctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	results := c.Watch(wctx)
+	stall := time.NewTimer(stallTimeout)
+	defer stall.Stop()
+	for {
+		select {
+		case r, ok := <-results:
+			if !ok {
+				return ctx.Err() == nil
+			}
+			if *last > 0 && r.Round() <= *last {
+				continue
+			}
+			select {
+			case ch <- r:
+				*last = r.Round()
+			case <-ctx.Done():
+				return false
+			}
+			if !stall.Stop() {
+				<-stall.C
+			}
+			stall.Reset(stallTimeout)
+		case <-stall.C:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+}
+
 // newWatchAggregator maintains state of consumers calling `Watch` so that a
 // single `watch` request is made to the underlying client.
 func newWatchAggregator(c Client, l log.Logger) *watchAggregator {
@@ -89,11 +154,29 @@ type subscriber struct {
 type watchAggregator struct {
 	Client
 	log log.Logger
+	// resources released by Close, e.g. the disk cache
+	closers []io.Closer
 
 	subscriberLock sync.Mutex
 	subscribers    []subscriber
 }
 
+// GetRange implements the RangeClient interface.
+func (c *watchAggregator) GetRange(ctx context.Context, from, to uint64) ([]Result, error) {
+	return GetRange(ctx, c.Client, from, to)
+}
+
+// Close releases the resources of the client.
+func (c *watchAggregator) Close() error {
+	var err error
+	for _, closer := range c.closers {
+		if cerr := closer.Close(); cerr != nil {
+			err = cerr
+		}
+	}
+	return err
+}
+
 func (c *watchAggregator) Watch(ctx context.Context) <-chan Result {
 	c.subscriberLock.Lock()
 	defer c.subscriberLock.Unlock()
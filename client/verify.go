@@ -0,0 +1,109 @@
+package client
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/drand/drand/beacon"
+	"github.com/drand/kyber"
+)
+
+// ErrRoundMismatch is returned when a response holds another round than the
+// one requested, e.g. when a relay replays an older beacon.
+type ErrRoundMismatch struct {
+	Requested uint64
+	Received  uint64
+}
+
+func (e *ErrRoundMismatch) Error() string {
+	return fmt.Sprintf("client: requested round %d, received round %d", e.Requested, e.Received)
+}
+
+// ErrInvalidSignature is returned when the signature of a beacon is missing or
+// does not verify against the distributed key of the group.
+type ErrInvalidSignature struct {
+	Round uint64
+	Err   error
+}
+
+func (e *ErrInvalidSignature) Error() string {
+	return fmt.Sprintf("client: invalid signature for round %d: %s", e.Round, e.Err)
+}
+
+// ErrInvalidRandomness is returned when the randomness of a beacon is not the
+// hash of its signature.
+type ErrInvalidRandomness struct {
+	Round uint64
+}
+
+func (e *ErrInvalidRandomness) Error() string {
+	return fmt.Sprintf("client: randomness of round %d does not match its signature", e.Round)
+}
+
+// ErrChainMismatch is returned when a beacon does not link to the beacon of a
+// neighbouring round.
+type ErrChainMismatch struct {
+	Round     uint64
+	Neighbour uint64
+}
+
+func (e *ErrChainMismatch) Error() string {
+	return fmt.Sprintf("client: round %d does not link to round %d", e.Round, e.Neighbour)
+}
+
+// verifySignature checks the signature of the beacon against the group key.
+func verifySignature(pub kyber.Point, r *RandomData) error {
+	if len(r.Signature) == 0 || len(r.PreviousSignature) == 0 {
+		return &ErrInvalidSignature{r.Rnd, errors.New("insufficent response")}
+	}
+	b := beacon.Beacon{
+		PreviousSig:  r.PreviousSignature,
+		Round:        r.Rnd,
+		Signature:    r.Signature,
+		UnchainedSig: r.UnchainedSignature,
+	}
+	if err := beacon.VerifyBeacon(pub, &b); err != nil {
+		return &ErrInvalidSignature{r.Rnd, err}
+	}
+	return nil
+}
+
+// verifyRandomness checks the randomness sent along the beacon, if any, is
+// the hash of its signature, and replaces it by the locally computed one.
+func verifyRandomness(r *RandomData) error {
+	expected := beacon.RandomnessFromSignature(r.Signature)
+	if len(r.Random) > 0 && !bytes.Equal(r.Random, expected) {
+		return &ErrInvalidRandomness{r.Rnd}
+	}
+	r.Random = expected
+	return nil
+}
+
+// verifyResponse checks the beacon received in response to a request for the
+// given round, or for the latest round if it is 0. In strict mode, the round
+// must be the one requested and the randomness must match the signature.
+func verifyResponse(pub kyber.Point, requested uint64, r *RandomData, strict bool) error {
+	if strict && requested != 0 && r.Rnd != requested {
+		return &ErrRoundMismatch{Requested: requested, Received: r.Rnd}
+	}
+	if err := verifySignature(pub, r); err != nil {
+		return err
+	}
+	if strict {
+		return verifyRandomness(r)
+	}
+	return nil
+}
+
+// verifyLinks checks the beacon links to the beacons of the neighbouring
+// rounds, when known.
+func verifyLinks(r, prev, next *RandomData) error {
+	if prev != nil && !bytes.Equal(prev.Signature, r.PreviousSignature) {
+		return &ErrChainMismatch{Round: r.Rnd, Neighbour: prev.Rnd}
+	}
+	if next != nil && !bytes.Equal(r.Signature, next.PreviousSignature) {
+		return &ErrChainMismatch{Round: r.Rnd, Neighbour: next.Rnd}
+	}
+	return nil
+}
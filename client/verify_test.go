@@ -0,0 +1,106 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/drand/drand/log"
+	json "github.com/nikkolasg/hexjson"
+)
+
+// mapClient serves the beacons of a map without verification.
+type mapClient struct {
+	MockClient
+	rounds map[uint64]*RandomData
+}
+
+func (m *mapClient) Get(ctx context.Context, round uint64) (Result, error) {
+	if r, ok := m.rounds[round]; ok {
+		return r, nil
+	}
+	return nil, errors.New("No result available")
+}
+
+func TestHTTPStrictVerification(t *testing.T) {
+	group, chain := fakeChain(t, 5)
+	invalidRandomness := *chain[3]
+	invalidRandomness.Random = []byte("not the randomness")
+	invalidSignature := *chain[4]
+	invalidSignature.Signature = chain[3].Signature
+	responses := map[uint64]*RandomData{
+		2: chain[1],
+		// replay of an older beacon
+		3: chain[1],
+		4: &invalidRandomness,
+		5: &invalidSignature,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		round, _ := strconv.ParseUint(strings.TrimPrefix(r.URL.Path, "/public/"), 10, 64)
+		json.NewEncoder(w).Encode(responses[round])
+	}))
+	defer server.Close()
+
+	c, err := NewHTTPClientWithGroup(server.URL, group, &http.Client{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Get(context.Background(), 2); err != nil {
+		t.Fatal(err)
+	}
+	var mismatch *ErrRoundMismatch
+	if _, err := c.Get(context.Background(), 3); !errors.As(err, &mismatch) || mismatch.Received != 2 {
+		t.Fatalf("expected a round mismatch, got %v", err)
+	}
+	var invalidRand *ErrInvalidRandomness
+	if _, err := c.Get(context.Background(), 4); !errors.As(err, &invalidRand) {
+		t.Fatalf("expected invalid randomness, got %v", err)
+	}
+	var invalidSig *ErrInvalidSignature
+	if _, err := c.Get(context.Background(), 5); !errors.As(err, &invalidSig) {
+		t.Fatalf("expected invalid signature, got %v", err)
+	}
+
+	// signatures are still verified when not strict
+	c.(*httpClient).strict = false
+	if r, err := c.Get(context.Background(), 3); err != nil || r.Round() != 2 {
+		t.Fatalf("replayed beacon should be accepted when not strict: %v", err)
+	}
+	if _, err := c.Get(context.Background(), 5); !errors.As(err, &invalidSig) {
+		t.Fatalf("expected invalid signature, got %v", err)
+	}
+}
+
+func TestCacheLinkVerification(t *testing.T) {
+	_, chain := fakeChain(t, 5)
+	unlinked := *chain[2]
+	unlinked.PreviousSignature = chain[0].Signature
+	inner := &mapClient{rounds: map[uint64]*RandomData{
+		2: chain[1],
+		3: &unlinked,
+		4: chain[3],
+	}}
+	c, err := NewCachingClient(inner, 10, log.DefaultLogger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.(*cachingClient).verifyLinks = true
+
+	for _, round := range []uint64{2, 4} {
+		if _, err := c.Get(context.Background(), round); err != nil {
+			t.Fatal(err)
+		}
+	}
+	var mismatch *ErrChainMismatch
+	if _, err := c.Get(context.Background(), 3); !errors.As(err, &mismatch) || mismatch.Neighbour != 2 {
+		t.Fatalf("expected a chain mismatch, got %v", err)
+	}
+	inner.rounds[3] = chain[2]
+	if _, err := c.Get(context.Background(), 3); err != nil {
+		t.Fatal(err)
+	}
+}
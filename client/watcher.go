@@ -0,0 +1,35 @@
+package client
+
+import (
+	"context"
+
+	"github.com/drand/drand/key"
+)
+
+// Watcher supplies the randomness of new rounds as it is produced, e.g. from
+// a push-based transport such as gossipsub.
+type Watcher interface {
+	// Watch returns new randomness as it becomes available. Results must be
+	// verified by the watcher.
+	Watch(ctx context.Context) <-chan Result
+}
+
+// WatcherCtor creates a Watcher once the group of the client is known.
+type WatcherCtor func(group *key.Group) (Watcher, error)
+
+// watcherClient serves Watch from a Watcher and all other requests from the
+// underlying client.
+type watcherClient struct {
+	Client
+	watcher Watcher
+}
+
+// Watch returns the randomness pushed by the watcher.
+func (c *watcherClient) Watch(ctx context.Context) <-chan Result {
+	return c.watcher.Watch(ctx)
+}
+
+// GetRange implements the RangeClient interface.
+func (c *watcherClient) GetRange(ctx context.Context, from, to uint64) ([]Result, error) {
+	return GetRange(ctx, c.Client, from, to)
+}
@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"strconv"
+	"time"
 
 	"github.com/drand/drand/client"
 	"github.com/urfave/cli/v2"
@@ -36,12 +38,18 @@ var roundFlag = &cli.IntFlag{
 	Usage: "request randomness for a specific round",
 }
 
+var timeFlag = &cli.StringFlag{
+	Name:  "time",
+	Usage: "request the randomness that was the latest at a specific time, as a UNIX timestamp or in RFC 3339 format",
+}
+
 func main() {
 	app := &cli.App{
-		Name:   "client",
-		Usage:  "CDN Drand client for loading randomness from an HTTP endpoint",
-		Flags:  []cli.Flag{urlFlag, hashFlag, insecureFlag, watchFlag, roundFlag},
-		Action: Client,
+		Name:     "client",
+		Usage:    "CDN Drand client for loading randomness from an HTTP endpoint",
+		Flags:    []cli.Flag{urlFlag, hashFlag, insecureFlag, watchFlag, roundFlag, timeFlag},
+		Action:   Client,
+		Commands: []*cli.Command{encryptCmd, decryptCmd, verifyCmd, privateCmd},
 	}
 
 	err := app.Run(os.Args)
@@ -52,25 +60,7 @@ func main() {
 
 // Client loads randomness from a server
 func Client(c *cli.Context) error {
-	if !c.IsSet(urlFlag.Name) {
-		return fmt.Errorf("A URL is required to learn randomness from an HTTP endpoint")
-	}
-
-	opts := []client.Option{}
-
-	if c.IsSet(hashFlag.Name) {
-		hex, err := hex.DecodeString(c.String(hashFlag.Name))
-		if err != nil {
-			return err
-		}
-		opts = append(opts, client.WithGroupHash(hex))
-	}
-	if c.IsSet(insecureFlag.Name) {
-		opts = append(opts, client.WithInsecureHTTPEndpoints([]string{c.String(urlFlag.Name)}))
-	} else {
-		opts = append(opts, client.WithHTTPEndpoints([]string{c.String(urlFlag.Name)}))
-	}
-	client, err := client.New(opts...)
+	client, err := newClient(c)
 	if err != nil {
 		return err
 	}
@@ -79,6 +69,19 @@ func Client(c *cli.Context) error {
 		return Watch(c, client)
 	}
 
+	if c.IsSet(timeFlag.Name) {
+		at, err := parseTime(c.String(timeFlag.Name))
+		if err != nil {
+			return fmt.Errorf("invalid time: %s", err)
+		}
+		rand, err := client.GetAt(context.Background(), at)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%v\n", rand)
+		return nil
+	}
+
 	round := uint64(0)
 	if c.IsSet(roundFlag.Name) {
 		round = uint64(c.Int(roundFlag.Name))
@@ -91,6 +94,37 @@ func Client(c *cli.Context) error {
 	return nil
 }
 
+// newClient creates a client from the URL and group flags.
+func newClient(c *cli.Context) (client.Client, error) {
+	if !c.IsSet(urlFlag.Name) {
+		return nil, fmt.Errorf("A URL is required to learn randomness from an HTTP endpoint")
+	}
+
+	opts := []client.Option{}
+
+	if c.IsSet(hashFlag.Name) {
+		hex, err := hex.DecodeString(c.String(hashFlag.Name))
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, client.WithGroupHash(hex))
+	}
+	if c.IsSet(insecureFlag.Name) {
+		opts = append(opts, client.WithInsecureHTTPEndpoints([]string{c.String(urlFlag.Name)}))
+	} else {
+		opts = append(opts, client.WithHTTPEndpoints([]string{c.String(urlFlag.Name)}))
+	}
+	return client.New(opts...)
+}
+
+// parseTime parses a UNIX timestamp in seconds or a time in RFC 3339 format.
+func parseTime(s string) (time.Time, error) {
+	if unix, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return time.Unix(unix, 0), nil
+	}
+	return time.Parse(time.RFC3339, s)
+}
+
 // Watch streams randomness from a client
 func Watch(c *cli.Context, client client.Client) error {
 	results := client.Watch(context.Background())
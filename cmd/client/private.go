@@ -0,0 +1,60 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/drand/drand/client"
+	"github.com/drand/drand/net"
+	"github.com/urfave/cli/v2"
+)
+
+var nodesFlag = &cli.IntFlag{
+	Name:  "nodes",
+	Usage: "number of nodes whose randomness is combined, all the nodes of the group by default",
+}
+
+var certsFlag = &cli.StringSliceFlag{
+	Name:  "tls-cert",
+	Usage: "path to a certificate trusted for the TLS connections to the nodes, in addition to the system certificates",
+}
+
+var privateCmd = &cli.Command{
+	Name: "private",
+	Usage: "request private randomness from the nodes of a group and combine it. " +
+		"The output stays private as long as one of the nodes is honest.",
+	Flags:  []cli.Flag{groupFlag, nodesFlag, certsFlag},
+	Action: private,
+}
+
+func private(c *cli.Context) error {
+	if !c.IsSet(groupFlag.Name) {
+		return fmt.Errorf("the group file is required to contact the nodes")
+	}
+	groupData, err := ioutil.ReadFile(c.String(groupFlag.Name))
+	if err != nil {
+		return err
+	}
+	group, err := client.ParseGroup(groupData)
+	if err != nil {
+		return fmt.Errorf("loading group file: %s", err)
+	}
+
+	var conns net.PublicClient
+	if c.IsSet(certsFlag.Name) {
+		certs := net.NewCertManager()
+		for _, path := range c.StringSlice(certsFlag.Name) {
+			if err := certs.Add(path); err != nil {
+				return err
+			}
+		}
+		conns = net.NewGrpcClientFromCertManager(certs)
+	}
+	rand, err := client.GetPrivate(context.Background(), group, c.Int(nodesFlag.Name), conns)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%x\n", rand)
+	return nil
+}
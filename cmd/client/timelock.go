@@ -0,0 +1,154 @@
+package main
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/drand/drand/beacon"
+	"github.com/drand/drand/client"
+	"github.com/drand/drand/key"
+	"github.com/drand/drand/timelock"
+	"github.com/urfave/cli/v2"
+)
+
+var groupConfFlag = &cli.StringFlag{
+	Name:  "group-conf",
+	Usage: "path to the group file of the drand network, whose key payloads are encrypted to",
+}
+
+var inFlag = &cli.StringFlag{
+	Name:  "in",
+	Usage: "file to read the input from, instead of the standard input",
+}
+
+var outFlag = &cli.StringFlag{
+	Name:  "out",
+	Usage: "file to write the output to, instead of the standard output",
+}
+
+var signatureFlag = &cli.StringFlag{
+	Name:  "signature",
+	Usage: "unchained signature (in hex) of the beacon of the round, instead of fetching it from the URL",
+}
+
+var waitFlag = &cli.BoolFlag{
+	Name:  "wait",
+	Usage: "wait for the round to be produced instead of failing",
+}
+
+var encryptCmd = &cli.Command{
+	Name: "encrypt",
+	Usage: "encrypt a payload that can only be decrypted once the beacon of a round is published. " +
+		"Decryption requires the unchained signature of the beacon.",
+	Flags:  []cli.Flag{groupConfFlag, roundFlag, timeFlag, inFlag, outFlag},
+	Action: encrypt,
+}
+
+var decryptCmd = &cli.Command{
+	Name:   "decrypt",
+	Usage:  "decrypt a payload encrypted to a round with the beacon of the round",
+	Flags:  []cli.Flag{urlFlag, hashFlag, insecureFlag, signatureFlag, waitFlag, inFlag, outFlag},
+	Action: decrypt,
+}
+
+func encrypt(c *cli.Context) error {
+	if !c.IsSet(groupConfFlag.Name) {
+		return fmt.Errorf("the group file is required to encrypt")
+	}
+	group := new(key.Group)
+	if err := key.Load(c.String(groupConfFlag.Name), group); err != nil {
+		return fmt.Errorf("loading group file: %s", err)
+	}
+	if group.PublicKey == nil {
+		return fmt.Errorf("group file does not contain the distributed key")
+	}
+
+	var round uint64
+	switch {
+	case c.IsSet(roundFlag.Name):
+		round = uint64(c.Int(roundFlag.Name))
+	case c.IsSet(timeFlag.Name):
+		at, err := parseTime(c.String(timeFlag.Name))
+		if err != nil {
+			return fmt.Errorf("invalid time: %s", err)
+		}
+		round = beacon.CurrentRound(at.Unix(), group.Period, group.GenesisTime)
+	default:
+		return fmt.Errorf("a round or a time is required to encrypt")
+	}
+
+	payload, err := readInput(c)
+	if err != nil {
+		return err
+	}
+	ciphertext, err := timelock.Encrypt(group.PublicKey.Key(), round, payload)
+	if err != nil {
+		return err
+	}
+	buff, err := ciphertext.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	return writeOutput(c, buff)
+}
+
+func decrypt(c *cli.Context) error {
+	buff, err := readInput(c)
+	if err != nil {
+		return err
+	}
+	ciphertext := new(timelock.Ciphertext)
+	if err := ciphertext.UnmarshalBinary(buff); err != nil {
+		return err
+	}
+
+	var signature []byte
+	if c.IsSet(signatureFlag.Name) {
+		if signature, err = hex.DecodeString(c.String(signatureFlag.Name)); err != nil {
+			return err
+		}
+	} else {
+		cl, err := newClient(c)
+		if err != nil {
+			return err
+		}
+		var res client.Result
+		if c.IsSet(waitFlag.Name) {
+			res, err = client.WaitFor(context.Background(), cl, ciphertext.Round)
+		} else {
+			res, err = cl.Get(context.Background(), ciphertext.Round)
+		}
+		if err != nil {
+			return err
+		}
+		rand, ok := res.(*client.RandomData)
+		if !ok || len(rand.UnchainedSignature) == 0 {
+			return fmt.Errorf("no unchained signature served for round %d", ciphertext.Round)
+		}
+		signature = rand.UnchainedSignature
+	}
+
+	payload, err := timelock.Decrypt(signature, ciphertext)
+	if err != nil {
+		return err
+	}
+	return writeOutput(c, payload)
+}
+
+func readInput(c *cli.Context) ([]byte, error) {
+	if c.IsSet(inFlag.Name) {
+		return ioutil.ReadFile(c.String(inFlag.Name))
+	}
+	return ioutil.ReadAll(os.Stdin)
+}
+
+func writeOutput(c *cli.Context, buff []byte) error {
+	if c.IsSet(outFlag.Name) {
+		return ioutil.WriteFile(c.String(outFlag.Name), buff, 0600)
+	}
+	_, err := os.Stdout.Write(buff)
+	return err
+}
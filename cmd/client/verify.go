@@ -0,0 +1,62 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/drand/drand/client"
+	"github.com/urfave/cli/v2"
+)
+
+var groupFlag = &cli.StringFlag{
+	Name:  "group",
+	Usage: "path to the group file, in TOML or in JSON as served by the /group endpoint",
+}
+
+var verifyCmd = &cli.Command{
+	Name: "verify",
+	Usage: "verify a beacon, or a JSON array of beacons of consecutive rounds, against a group without contacting any node. " +
+		"Reads the beacons from the file given as argument, or from the standard input.",
+	ArgsUsage: "[beacon.json]",
+	Flags:     []cli.Flag{groupFlag},
+	Action:    verify,
+}
+
+func verify(c *cli.Context) error {
+	if !c.IsSet(groupFlag.Name) {
+		return fmt.Errorf("the group file is required to verify beacons")
+	}
+	groupData, err := ioutil.ReadFile(c.String(groupFlag.Name))
+	if err != nil {
+		return err
+	}
+	group, err := client.ParseGroup(groupData)
+	if err != nil {
+		return fmt.Errorf("loading group file: %s", err)
+	}
+
+	var data []byte
+	if c.Args().Present() {
+		data, err = ioutil.ReadFile(c.Args().First())
+	} else {
+		data, err = ioutil.ReadAll(os.Stdin)
+	}
+	if err != nil {
+		return err
+	}
+	verification, err := client.VerifyResult(group, data)
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(verification); err != nil {
+		return err
+	}
+	if !verification.Valid {
+		return fmt.Errorf("verification failed")
+	}
+	return nil
+}
@@ -3,11 +3,16 @@ package client
 import (
 	"context"
 	"sync"
+	"time"
 
+	"github.com/drand/drand/beacon"
+	dclient "github.com/drand/drand/client"
 	"github.com/drand/drand/cmd/relay-gossip/lp2p"
+	"github.com/drand/drand/key"
 	"github.com/drand/drand/protobuf/drand"
 	"github.com/gogo/protobuf/proto"
 	logging "github.com/ipfs/go-log/v2"
+	"github.com/libp2p/go-libp2p-core/peer"
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
 	"golang.org/x/xerrors"
 )
@@ -16,9 +21,20 @@ var (
 	log = logging.Logger("drand-client")
 )
 
+// maxRoundLag is the number of rounds a message may lag behind the current
+// round before being considered stale and rejected.
+const maxRoundLag = 1
+
+var _ dclient.Client = (*Client)(nil)
+
+// Client is a drand client receiving the randomness published on a gossipsub
+// topic. Every message is verified against the group before being delivered.
 type Client struct {
 	cancel func()
-	latest uint64
+	group  *key.Group
+
+	latestLk sync.RWMutex
+	latest   *drand.PublicRandResponse
 
 	subs struct {
 		sync.Mutex
@@ -26,9 +42,17 @@ type Client struct {
 	}
 }
 
-// NewWtihPubsub creates a gossip randomness client.
-func NewWithPubsub(ps *pubsub.PubSub, networkName string) (*Client, error) {
-	t, err := ps.Join(lp2p.PubSubTopic(networkName))
+// NewWithPubsub creates a gossip randomness client verifying the randomness
+// against the given group.
+func NewWithPubsub(ps *pubsub.PubSub, group *key.Group, networkName string) (*Client, error) {
+	if group == nil || group.PublicKey == nil {
+		return nil, xerrors.New("a group with a distributed key is required to verify randomness")
+	}
+	topic := lp2p.PubSubTopic(networkName)
+	if err := ps.RegisterTopicValidator(topic, randomnessValidator(group)); err != nil {
+		return nil, xerrors.Errorf("registering validator: %w", err)
+	}
+	t, err := ps.Join(topic)
 	if err != nil {
 		return nil, xerrors.Errorf("joining pubsub: %w", err)
 	}
@@ -40,6 +64,7 @@ func NewWithPubsub(ps *pubsub.PubSub, networkName string) (*Client, error) {
 	ctx, cancel := context.WithCancel(context.Background())
 	c := &Client{
 		cancel: cancel,
+		group:  group,
 	}
 	c.subs.M = make(map[*int]chan drand.PublicRandResponse)
 
@@ -53,14 +78,16 @@ func NewWithPubsub(ps *pubsub.PubSub, networkName string) (*Client, error) {
 				}
 				c.subs.M = make(map[*int]chan drand.PublicRandResponse)
 				c.subs.Unlock()
-				t.Close()
 				s.Cancel()
+				t.Close()
+				ps.UnregisterTopicValidator(topic)
 				return
 			}
 			if err != nil {
 				log.Warnf("topic.Next error: %+v", err)
 				continue
 			}
+			// messages are verified by the validator before being delivered
 			var rand drand.PublicRandResponse
 			err = proto.Unmarshal(msg.Data, &rand)
 			if err != nil {
@@ -68,12 +95,13 @@ func NewWithPubsub(ps *pubsub.PubSub, networkName string) (*Client, error) {
 				continue
 			}
 
-			// TODO: verification, need to pass drand network public key in
-
-			if c.latest >= rand.Round {
+			c.latestLk.Lock()
+			if c.latest != nil && c.latest.Round >= rand.Round {
+				c.latestLk.Unlock()
 				continue
 			}
-			c.latest = rand.Round
+			c.latest = &rand
+			c.latestLk.Unlock()
 
 			c.subs.Lock()
 			for _, ch := range c.subs.M {
@@ -84,13 +112,55 @@ func NewWithPubsub(ps *pubsub.PubSub, networkName string) (*Client, error) {
 				}
 			}
 			c.subs.Unlock()
-
 		}
 	}()
 
 	return c, nil
 }
 
+// randomnessValidator returns a pubsub validator rejecting the messages that
+// are not valid beacons of the group, or whose round is in the future or
+// stale. Rejected messages penalise the peers that sent them.
+func randomnessValidator(group *key.Group) func(context.Context, peer.ID, *pubsub.Message) bool {
+	return func(ctx context.Context, p peer.ID, m *pubsub.Message) bool {
+		var rand drand.PublicRandResponse
+		if err := proto.Unmarshal(m.Data, &rand); err != nil {
+			log.Warnf("invalid randomness message from %s: %+v", p, err)
+			return false
+		}
+		current := beacon.CurrentRound(time.Now().Unix(), group.Period, group.GenesisTime)
+		// allow for one round of clock drift
+		if rand.Round > current+1 {
+			log.Warnf("future round %d from %s, current round is %d", rand.Round, p, current)
+			return false
+		}
+		if rand.Round+maxRoundLag < current {
+			log.Debugf("stale round %d from %s, current round is %d", rand.Round, p, current)
+			return false
+		}
+		b := &beacon.Beacon{
+			Round:        rand.Round,
+			PreviousSig:  rand.PreviousSignature,
+			Signature:    rand.Signature,
+			UnchainedSig: rand.UnchainedSignature,
+		}
+		if err := beacon.VerifyBeacon(group.PublicKey.Key(), b); err != nil {
+			log.Warnf("invalid beacon for round %d from %s: %+v", rand.Round, p, err)
+			return false
+		}
+		return true
+	}
+}
+
+// WithPubsub configures a drand client to receive new randomness from the
+// gossipsub topic of the network, verified against the group of the client.
+// Past rounds are still fetched from the HTTP endpoints of the client.
+func WithPubsub(ps *pubsub.PubSub, networkName string) dclient.Option {
+	return dclient.WithWatcher(func(group *key.Group) (dclient.Watcher, error) {
+		return NewWithPubsub(ps, group, networkName)
+	})
+}
+
 type UnsubFunc func()
 
 // Sub subscribes to notfications about new randomness.
@@ -109,16 +179,88 @@ func (c *Client) Sub(ch chan drand.PublicRandResponse) UnsubFunc {
 
 	return func() {
 		c.subs.Lock()
-		delete(c.subs.M, id)
-		close(ch)
+		if _, ok := c.subs.M[id]; ok {
+			delete(c.subs.M, id)
+			close(ch)
+		}
 		c.subs.Unlock()
 	}
 }
 
+// Get implements the client.Client interface. The gossip client only knows
+// the latest randomness received: it returns it when round is 0 or the latest
+// round, and an error otherwise.
+func (c *Client) Get(ctx context.Context, round uint64) (dclient.Result, error) {
+	c.latestLk.RLock()
+	latest := c.latest
+	c.latestLk.RUnlock()
+	if latest == nil {
+		return nil, xerrors.New("no randomness received yet")
+	}
+	if round != 0 && round != latest.Round {
+		return nil, xerrors.Errorf("round %d not available, latest round received is %d", round, latest.Round)
+	}
+	return toResult(c.group, latest), nil
+}
+
+// GetAt implements the client.Client interface. Like Get, it can only return
+// the latest randomness received.
+func (c *Client) GetAt(ctx context.Context, t time.Time) (dclient.Result, error) {
+	round, err := beacon.RoundAtTime(t.Unix(), time.Now().Unix(), c.group.Period, c.group.GenesisTime)
+	if err != nil {
+		return nil, err
+	}
+	return c.Get(ctx, round)
+}
+
+// Watch implements the client.Client interface. It returns the randomness
+// received on the topic until the context is done or the client is closed.
+func (c *Client) Watch(ctx context.Context) <-chan dclient.Result {
+	in := make(chan drand.PublicRandResponse, 5)
+	unsub := c.Sub(in)
+	out := make(chan dclient.Result, 5)
+	go func() {
+		defer close(out)
+		defer unsub()
+		for {
+			select {
+			case rand, ok := <-in:
+				if !ok {
+					return
+				}
+				select {
+				case out <- toResult(c.group, &rand):
+				default:
+					log.Warn("randomness notification dropped due to a full channel")
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// RoundAt implements the client.Client interface.
+func (c *Client) RoundAt(t time.Time) uint64 {
+	return beacon.CurrentRound(t.Unix(), c.group.Period, c.group.GenesisTime)
+}
+
 // Close stops Client, cancels PubSub subscription and closes the topic.
 func (c *Client) Close() error {
 	c.cancel()
 	return nil
 }
 
+func toResult(group *key.Group, rand *drand.PublicRandResponse) dclient.Result {
+	return &dclient.RandomData{
+		Rnd:                rand.Round,
+		Random:             beacon.RandomnessFromSignature(rand.Signature),
+		Signature:          rand.Signature,
+		PreviousSignature:  rand.PreviousSignature,
+		Time:               beacon.TimeOfRound(group.Period, group.GenesisTime, rand.Round),
+		UnchainedSignature: rand.UnchainedSignature,
+	}
+}
+
 // TODO: New for users without libp2p already running
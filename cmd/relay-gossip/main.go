@@ -1,15 +1,18 @@
 package main
 
 import (
+	"bytes"
 	"context"
 	"crypto/rand"
 	"crypto/tls"
+	"encoding/hex"
 	"fmt"
 	"os"
 	"time"
 
 	"github.com/drand/drand/cmd/relay-gossip/client"
 	"github.com/drand/drand/cmd/relay-gossip/lp2p"
+	"github.com/drand/drand/key"
 	"github.com/drand/drand/protobuf/drand"
 	"github.com/golang/protobuf/proto"
 	"github.com/ipfs/go-datastore"
@@ -194,15 +197,40 @@ func workRelay(client drand.PublicClient, t *pubsub.Topic) error {
 
 }
 
+var groupConfFlag = &cli.StringFlag{
+	Name:     "group-conf",
+	Usage:    "path to the group file of the drand network, used to verify randomness",
+	Required: true,
+}
+
+var hashFlag = &cli.StringFlag{
+	Name:  "hash",
+	Usage: "hash (in hex) of the expected group, checked against the group file",
+}
+
 var clientCmd = &cli.Command{
 	Name:  "client",
-	Flags: []cli.Flag{peerWithFlag},
+	Flags: []cli.Flag{peerWithFlag, groupConfFlag, hashFlag},
 	Action: func(cctx *cli.Context) error {
 		bootstrap, err := parseMultiaddrSlice(cctx.StringSlice(peerWithFlag.Name))
 		if err != nil {
 			return xerrors.Errorf("parsing peer-with: %w", err)
 		}
 
+		group := new(key.Group)
+		if err := key.Load(cctx.String(groupConfFlag.Name), group); err != nil {
+			return xerrors.Errorf("loading group file: %w", err)
+		}
+		if cctx.IsSet(hashFlag.Name) {
+			hash, err := hex.DecodeString(cctx.String(hashFlag.Name))
+			if err != nil {
+				return xerrors.Errorf("decoding hash: %w", err)
+			}
+			if !bytes.Equal(hash, group.Hash()) {
+				return xerrors.Errorf("group file does not match the expected hash (%x vs %x)", group.Hash(), hash)
+			}
+		}
+
 		priv, _, err := crypto.GenerateEd25519Key(rand.Reader)
 		if err != nil {
 			return xerrors.Errorf("generating ed25519 key: %w", err)
@@ -213,22 +241,13 @@ var clientCmd = &cli.Command{
 			return xerrors.Errorf("constructing host: %w", err)
 		}
 
-		c, err := client.NewWithPubsub(ps, cctx.String("network-name"))
+		c, err := client.NewWithPubsub(ps, group, cctx.String("network-name"))
 		if err != nil {
 			return xerrors.Errorf("constructing client: %w", err)
 		}
 
-		var notifChan <-chan drand.PublicRandResponse
-		var unsub client.UnsubFunc
-		{
-			ch := make(chan drand.PublicRandResponse, 5)
-			notifChan = ch
-			unsub = c.Sub(ch)
-		}
-		_ = unsub
-
-		for rand := range notifChan {
-			fmt.Printf("got randomness: Round %d: %X\n", rand.Round, rand.Signature[:16])
+		for rand := range c.Watch(context.Background()) {
+			fmt.Printf("got randomness: Round %d: %X\n", rand.Round(), rand.Randomness()[:16])
 		}
 		return nil
 	},
@@ -3,11 +3,15 @@ package main
 import (
 	"crypto/tls"
 	"fmt"
+	"io/ioutil"
 	"net"
 	"net/http"
 	"os"
 
+	"github.com/drand/drand/beacon"
+	"github.com/drand/drand/client"
 	dhttp "github.com/drand/drand/http"
+	"github.com/drand/drand/key"
 	"github.com/drand/drand/log"
 	drand "github.com/drand/drand/protobuf/drand"
 
@@ -22,9 +26,14 @@ var listenFlag = &cli.StringFlag{
 	Usage: "local host:port to bind the listener",
 }
 
-var connectFlag = &cli.StringFlag{
+var connectFlag = &cli.StringSliceFlag{
 	Name:  "connect",
-	Usage: "host:port to dial to a GRPC drand public API",
+	Usage: "host:port to dial to a GRPC drand public API, can be given multiple times to fail over between nodes",
+}
+
+var groupConfFlag = &cli.StringFlag{
+	Name:  "group-conf",
+	Usage: "group file whose key verifies the beacons, the nodes of the group are dialed unless 'connect' hosts are given",
 }
 
 var certFlag = &cli.StringFlag{
@@ -37,34 +46,63 @@ var insecureFlag = &cli.BoolFlag{
 	Usage: "Allow non-tls connections to GRPC server",
 }
 
+var dbFlag = &cli.StringFlag{
+	Name:  "db",
+	Usage: "folder of a database keeping the beacons, to serve historical rounds without the upstream nodes",
+}
+
 var accessLogFlag = &cli.StringFlag{
 	Name:  "access-log",
 	Usage: "file to log http accesses to",
 }
 
-// Relay a GRPC connection to an HTTP server.
+// Relay GRPC connections to an HTTP server.
 func Relay(c *cli.Context) error {
-	if !c.IsSet(connectFlag.Name) {
-		return fmt.Errorf("A 'connect' host must be provided")
+	var group *key.Group
+	if c.IsSet(groupConfFlag.Name) {
+		data, err := ioutil.ReadFile(c.String(groupConfFlag.Name))
+		if err != nil {
+			return err
+		}
+		if group, err = client.ParseGroup(data); err != nil {
+			return fmt.Errorf("Failed to load group file: %w", err)
+		}
 	}
 
-	opts := []grpc.DialOption{}
-	if c.IsSet(certFlag.Name) {
-		creds, _ := credentials.NewClientTLSFromFile(c.String(certFlag.Name), "")
-		opts = append(opts, grpc.WithTransportCredentials(creds))
-	} else if c.Bool(insecureFlag.Name) {
-		opts = append(opts, grpc.WithInsecure())
+	var clients []drand.PublicClient
+	if c.IsSet(connectFlag.Name) {
+		for _, addr := range c.StringSlice(connectFlag.Name) {
+			conn, err := grpc.Dial(addr, dialOptions(c, c.IsSet(certFlag.Name) || !c.Bool(insecureFlag.Name))...)
+			if err != nil {
+				return fmt.Errorf("Failed to connect to group member: %w", err)
+			}
+			clients = append(clients, drand.NewPublicClient(conn))
+		}
+	} else if group != nil {
+		for _, node := range group.Nodes {
+			conn, err := grpc.Dial(node.Address(), dialOptions(c, node.IsTLS())...)
+			if err != nil {
+				return fmt.Errorf("Failed to connect to group member: %w", err)
+			}
+			clients = append(clients, drand.NewPublicClient(conn))
+		}
 	} else {
-		opts = append(opts, grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{})))
-	}
-	conn, err := grpc.Dial(c.String(connectFlag.Name), opts...)
-	if err != nil {
-		return fmt.Errorf("Failed to connect to group member: %w", err)
+		return fmt.Errorf("A 'connect' host or a 'group-conf' file must be provided")
 	}
 
-	client := drand.NewPublicClient(conn)
+	var store beacon.Store
+	if c.IsSet(dbFlag.Name) {
+		if err := os.MkdirAll(c.String(dbFlag.Name), 0700); err != nil {
+			return err
+		}
+		var err error
+		if store, err = beacon.NewBoltStore(c.String(dbFlag.Name), nil); err != nil {
+			return fmt.Errorf("Failed to open database: %w", err)
+		}
+		defer store.Close()
+	}
 
-	handler, err := dhttp.New(c.Context, client, log.DefaultLogger.With("binary", "relay"))
+	handler, err := dhttp.NewWithUpstreams(c.Context, clients, group, store, log.DefaultLogger.With("binary", "relay"))
 	if err != nil {
 		return fmt.Errorf("Failed to create rest handler: %w", err)
 	}
@@ -90,11 +128,22 @@ func Relay(c *cli.Context) error {
 	return http.Serve(listener, handler)
 }
 
+func dialOptions(c *cli.Context, tlsConn bool) []grpc.DialOption {
+	if !tlsConn {
+		return []grpc.DialOption{grpc.WithInsecure()}
+	}
+	if c.IsSet(certFlag.Name) {
+		creds, _ := credentials.NewClientTLSFromFile(c.String(certFlag.Name), "")
+		return []grpc.DialOption{grpc.WithTransportCredentials(creds)}
+	}
+	return []grpc.DialOption{grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{}))}
+}
+
 func main() {
 	app := &cli.App{
 		Name:   "relay",
 		Usage:  "Relay a Drand group to a public HTTP Rest API",
-		Flags:  []cli.Flag{listenFlag, connectFlag, certFlag, insecureFlag, accessLogFlag},
+		Flags:  []cli.Flag{listenFlag, connectFlag, groupConfFlag, certFlag, insecureFlag, dbFlag, accessLogFlag},
 		Action: Relay,
 	}
 
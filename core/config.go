@@ -1,6 +1,7 @@
 package core
 
 import (
+	"fmt"
 	"path"
 	"time"
 
@@ -11,6 +12,8 @@ import (
 	clock "github.com/jonboulle/clockwork"
 	bolt "go.etcd.io/bbolt"
 	"google.golang.org/grpc"
+	// registers the SQLite driver used by the SQLiteEngine
+	_ "modernc.org/sqlite"
 )
 
 // ConfigOption is a function that applies a specific setting to a Config.
@@ -27,6 +30,9 @@ type Config struct {
 	callOpts          []grpc.CallOption
 	dkgTimeout        time.Duration
 	boltOpts          *bolt.Options
+	dbEngine          string
+	memoryStoreSize   int
+	retention         beacon.RetentionPolicy
 	beaconCbs         []func(*beacon.Beacon)
 	dkgCallback       func(*key.Share)
 	insecure          bool
@@ -52,9 +58,11 @@ func NewConfig(opts ...ConfigOption) *Config {
 		},
 		dkgTimeout: DefaultDKGTimeout,
 		//certmanager: net.NewCertManager(),
-		controlPort: DefaultControlPort,
-		logger:      log.DefaultLogger,
-		clock:       clock.NewRealClock(),
+		controlPort:     DefaultControlPort,
+		logger:          log.DefaultLogger,
+		clock:           clock.NewRealClock(),
+		dbEngine:        DefaultDBEngine,
+		memoryStoreSize: DefaultMemoryStoreSize,
 	}
 	d.dbFolder = path.Join(d.configFolder, DefaultDbFolder)
 	for i := range opts {
@@ -154,6 +162,52 @@ func (c *Config) BoltOptions() *bolt.Options {
 	return c.boltOpts
 }
 
+// WithDBEngine sets the database engine used to store random beacons. It must
+// be one of BoltEngine, SQLiteEngine or MemoryEngine.
+func WithDBEngine(engine string) ConfigOption {
+	return func(d *Config) {
+		d.dbEngine = engine
+	}
+}
+
+// DBEngine returns the database engine used to store random beacons.
+func (d *Config) DBEngine() string {
+	return d.dbEngine
+}
+
+// WithMemoryStoreSize sets the number of rounds kept when using the
+// MemoryEngine database engine.
+func WithMemoryStoreSize(size int) ConfigOption {
+	return func(d *Config) {
+		d.memoryStoreSize = size
+	}
+}
+
+// WithRetention sets the retention policy of the beacon store: the node only
+// keeps the given number of most recent rounds and the beacons generated during
+// the given period. A zero value disables the corresponding limit. By default,
+// the whole chain is kept.
+func WithRetention(rounds uint64, period time.Duration) ConfigOption {
+	return func(d *Config) {
+		d.retention = beacon.RetentionPolicy{Rounds: rounds, Period: period}
+	}
+}
+
+// NewStore returns the beacon store using the database engine of the given
+// config. The database is created in the DBFolder if needed.
+func NewStore(c *Config) (beacon.Store, error) {
+	switch c.dbEngine {
+	case BoltEngine:
+		return beacon.NewBoltStore(c.dbFolder, c.boltOpts)
+	case SQLiteEngine:
+		return beacon.NewSQLiteStore(c.dbFolder)
+	case MemoryEngine:
+		return beacon.NewMemoryStore(c.memoryStoreSize)
+	default:
+		return nil, fmt.Errorf("unknown database engine %q", c.dbEngine)
+	}
+}
+
 // WithDbFolder sets the path folder for the db file. This path is NOT relative
 // to the DrandFolder path if set.
 func WithDbFolder(folder string) ConfigOption {
@@ -22,6 +22,24 @@ func DefaultConfigFolder() string {
 // default it is relative to the DefaultConfigFolder path.
 const DefaultDbFolder = "db"
 
+// Database engines that can be used to store the beacons.
+const (
+	// BoltEngine stores beacons in a boltdb file in the DefaultDbFolder.
+	BoltEngine = "bolt"
+	// SQLiteEngine stores beacons in a SQLite database in the
+	// DefaultDbFolder.
+	SQLiteEngine = "sqlite"
+	// MemoryEngine only keeps the most recent beacons in memory.
+	MemoryEngine = "memory"
+)
+
+// DefaultDBEngine is the database engine used by default to store beacons.
+const DefaultDBEngine = BoltEngine
+
+// DefaultMemoryStoreSize is the number of rounds kept in memory by default when
+// using the MemoryEngine.
+const DefaultMemoryStoreSize = 1000
+
 // DefaultBeaconPeriod is the period in which the beacon logic creates new
 // random beacon.
 const DefaultBeaconPeriod time.Duration = 1 * time.Minute
@@ -65,6 +83,10 @@ var DefaultGenesisOffset = DefaultDKGTimeout * time.Duration(3)
 // has to keep the same period.
 var DefaultResharingOffset = 30 * time.Second
 
+// MaxRangeLength is the maximum number of beacons sent in reply to a single
+// range request.
+var MaxRangeLength = 1000
+
 // Keep the most recents beacons
 // XXX unused for now
 var DefaultBeaconCacheLength = 10
@@ -13,10 +13,11 @@ import (
 
 func beaconToProto(b *beacon.Beacon) *drand.PublicRandResponse {
 	return &drand.PublicRandResponse{
-		Round:             b.Round,
-		Signature:         b.Signature,
-		PreviousSignature: b.PreviousSig,
-		Randomness:        b.Randomness(),
+		Round:              b.Round,
+		Signature:          b.Signature,
+		PreviousSignature:  b.PreviousSig,
+		Randomness:         b.Randomness(),
+		UnchainedSignature: b.UnchainedSig,
 	}
 }
 
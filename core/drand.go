@@ -107,7 +107,7 @@ func initDrand(s key.Store, c *Config) (*Drand, error) {
 		var err error
 		d.log.Info("network", "tls-disable")
 		if pubAddr != "" {
-			handler, err := http.New(ctx, &drandProxy{d}, logger.With("server", "http"))
+			handler, err := http.New(ctx, &drandProxy{r: d, callbacks: d.callbacks}, logger.With("server", "http"))
 			if err != nil {
 				return nil, err
 			}
@@ -122,7 +122,7 @@ func initDrand(s key.Store, c *Config) (*Drand, error) {
 		var err error
 		d.log.Info("network", "tls-enabled")
 		if pubAddr != "" {
-			handler, err := http.New(ctx, &drandProxy{d}, logger.With("server", "http"))
+			handler, err := http.New(ctx, &drandProxy{r: d, callbacks: d.callbacks}, logger.With("server", "http"))
 			if err != nil {
 				return nil, err
 			}
@@ -325,7 +325,7 @@ func (d *Drand) newBeacon() (*beacon.Handler, error) {
 	d.state.Lock()
 	defer d.state.Unlock()
 	fs.CreateSecureFolder(d.opts.DBFolder())
-	store, err := beacon.NewBoltStore(d.opts.dbFolder, d.opts.boltOpts)
+	store, err := NewStore(d.opts)
 	if err != nil {
 		return nil, err
 	}
@@ -336,10 +336,11 @@ func (d *Drand) newBeacon() (*beacon.Handler, error) {
 		return nil, fmt.Errorf("public key %s not found in group", pub)
 	}
 	conf := &beacon.Config{
-		Public: node,
-		Group:  d.group,
-		Share:  d.share,
-		Clock:  d.opts.clock,
+		Public:    node,
+		Group:     d.group,
+		Share:     d.share,
+		Clock:     d.opts.clock,
+		Retention: d.opts.retention,
 	}
 	beacon, err := beacon.NewHandler(d.privGateway.ProtocolClient, store, conf, d.log)
 	if err != nil {
@@ -3,7 +3,10 @@ package core
 import (
 	"context"
 	"errors"
+	"fmt"
+	"io"
 
+	"github.com/drand/drand/beacon"
 	"github.com/drand/drand/protobuf/drand"
 	"google.golang.org/grpc"
 )
@@ -12,16 +15,46 @@ import (
 // and a Public Client (the client consumed by the HTTP API)
 type drandProxy struct {
 	r drand.PublicServer
+	// callbacks of the node, fed with its new beacons
+	callbacks *callbackManager
 }
 
+// proxyStreamBuffer is the number of new beacons buffered for a stream. The
+// beacons arriving while the buffer is full are dropped.
+const proxyStreamBuffer = 10
+
 var _ drand.PublicClient = (*drandProxy)(nil)
 
 func (d *drandProxy) PublicRand(c context.Context, r *drand.PublicRandRequest, opts ...grpc.CallOption) (*drand.PublicRandResponse, error) {
 	return d.r.PublicRand(c, r)
 }
+
+// PublicRandStream streams the new beacons of the node, as they are given to
+// its callbacks, until the context is done.
 func (d *drandProxy) PublicRandStream(ctx context.Context, in *drand.PublicRandRequest, opts ...grpc.CallOption) (drand.Public_PublicRandStreamClient, error) {
-	return nil, errors.New("streaming is not supported on HTTP endpoint")
+	if d.callbacks == nil {
+		return nil, errors.New("streaming is not supported on HTTP endpoint")
+	}
+	stream := &proxyStream{ctx: ctx, beacons: make(chan *beacon.Beacon, proxyStreamBuffer)}
+	id := fmt.Sprintf("http-proxy-%p", stream)
+	d.callbacks.AddCallback(id, stream.push)
+	go func() {
+		<-ctx.Done()
+		d.callbacks.DelCallback(id)
+	}()
+	return stream, nil
+}
+
+// PublicRandRange runs the range request on the node and replays the beacons
+// it sent. Ranges are bounded so they can be buffered.
+func (d *drandProxy) PublicRandRange(ctx context.Context, in *drand.PublicRandRangeRequest, opts ...grpc.CallOption) (drand.Public_PublicRandRangeClient, error) {
+	stream := &proxyRangeStream{ctx: ctx}
+	if err := d.r.PublicRandRange(in, stream); err != nil {
+		return nil, err
+	}
+	return stream, nil
 }
+
 func (d *drandProxy) PrivateRand(c context.Context, r *drand.PrivateRandRequest, opts ...grpc.CallOption) (*drand.PrivateRandResponse, error) {
 	return d.r.PrivateRand(c, r)
 }
@@ -35,3 +68,78 @@ func (d *drandProxy) Home(c context.Context, r *drand.HomeRequest, opts ...grpc.
 func (d *drandProxy) Group(c context.Context, r *drand.GroupRequest, opts ...grpc.CallOption) (*drand.GroupPacket, error) {
 	return d.r.Group(c, r)
 }
+func (d *drandProxy) Health(c context.Context, r *drand.HealthRequest, opts ...grpc.CallOption) (*drand.HealthResponse, error) {
+	return d.r.Health(c, r)
+}
+func (d *drandProxy) Ready(c context.Context, r *drand.ReadyRequest, opts ...grpc.CallOption) (*drand.ReadyResponse, error) {
+	return d.r.Ready(c, r)
+}
+func (d *drandProxy) Info(c context.Context, r *drand.InfoRequest, opts ...grpc.CallOption) (*drand.InfoResponse, error) {
+	return d.r.Info(c, r)
+}
+
+// proxyStream is the client side of a stream of the new beacons of the node.
+// Only the methods used by the HTTP API are implemented.
+type proxyStream struct {
+	grpc.ClientStream
+	ctx     context.Context
+	beacons chan *beacon.Beacon
+}
+
+// push is the callback receiving the new beacons of the node. It never blocks
+// the callbacks of the node: beacons are dropped if the reader is too slow.
+func (p *proxyStream) push(b *beacon.Beacon) {
+	select {
+	case p.beacons <- b:
+	default:
+	}
+}
+
+func (p *proxyStream) Recv() (*drand.PublicRandResponse, error) {
+	select {
+	case b := <-p.beacons:
+		return beaconToProto(b), nil
+	case <-p.ctx.Done():
+		return nil, p.ctx.Err()
+	}
+}
+
+func (p *proxyStream) Context() context.Context {
+	return p.ctx
+}
+
+// proxyRangeStream is both the server and the client side of a range stream.
+// The server side buffers the beacons sent, then the client side replays them.
+// Only the methods used by the server and the HTTP API are implemented.
+type proxyRangeStream struct {
+	grpc.ServerStream
+	grpc.ClientStream
+	ctx   context.Context
+	resps []*drand.PublicRandResponse
+}
+
+func (p *proxyRangeStream) Send(r *drand.PublicRandResponse) error {
+	p.resps = append(p.resps, r)
+	return nil
+}
+
+func (p *proxyRangeStream) Recv() (*drand.PublicRandResponse, error) {
+	if len(p.resps) == 0 {
+		return nil, io.EOF
+	}
+	r := p.resps[0]
+	p.resps = p.resps[1:]
+	return r, nil
+}
+
+func (p *proxyRangeStream) Context() context.Context {
+	return p.ctx
+}
+
+func (p *proxyRangeStream) SendMsg(m interface{}) error {
+	return errors.New("not supported by proxy stream")
+}
+
+func (p *proxyRangeStream) RecvMsg(m interface{}) error {
+	return errors.New("not supported by proxy stream")
+}
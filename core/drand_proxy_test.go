@@ -0,0 +1,71 @@
+package core
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/drand/drand/beacon"
+	dhttp "github.com/drand/drand/http"
+	"github.com/drand/drand/protobuf/drand"
+	"github.com/drand/drand/test/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDrandProxyStream(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	listener, node := mock.NewMockGRPCPublicServer("127.0.0.1:0", false)
+	defer listener.Stop(ctx)
+	callbacks := newCallbackManager()
+
+	handler, err := dhttp.New(ctx, &drandProxy{r: node, callbacks: callbacks}, nil)
+	require.NoError(t, err)
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/public/stream")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	events := make(chan uint64)
+	go func() {
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			if id := strings.TrimPrefix(scanner.Text(), "id: "); id != scanner.Text() {
+				round, _ := strconv.ParseUint(id, 10, 64)
+				events <- round
+			}
+		}
+		close(events)
+	}()
+	first := <-events
+	require.NotZero(t, first)
+
+	// the node produces new beacons until one is streamed
+	produced := time.NewTicker(100 * time.Millisecond)
+	defer produced.Stop()
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case <-produced.C:
+			next, err := node.PublicRand(ctx, &drand.PublicRandRequest{})
+			require.NoError(t, err)
+			callbacks.NewBeacon(&beacon.Beacon{
+				PreviousSig: next.GetPreviousSignature(),
+				Round:       next.GetRound(),
+				Signature:   next.GetSignature(),
+			})
+		case round, ok := <-events:
+			require.True(t, ok, "stream closed")
+			require.True(t, round > first, "expected a round after %d, got %d", first, round)
+			return
+		case <-timeout:
+			t.Fatal("new beacons of the node should be streamed")
+		}
+	}
+}
@@ -11,7 +11,9 @@ import (
 	"github.com/drand/drand/key"
 	"github.com/drand/drand/protobuf/drand"
 	"github.com/drand/kyber/encrypt/ecies"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
 )
 
 // Setup is the public method to call during a DKG protocol.
@@ -94,15 +96,28 @@ func (d *Drand) PublicRand(c context.Context, in *drand.PublicRandRequest) (*dra
 	}
 	var r *beacon.Beacon
 	var err error
-	if in.GetRound() == 0 {
+	round := in.GetRound()
+	if in.GetAtTime() != 0 {
+		// resolve the round that was current at the requested time
+		if round != 0 {
+			return nil, status.Error(codes.InvalidArgument, "drand: round and time can not be both specified")
+		}
+		now := d.opts.clock.Now().Unix()
+		round, err = beacon.RoundAtTime(in.GetAtTime(), now, d.group.Period, d.group.GenesisTime)
+		if err != nil {
+			d.log.Debug("public_rand", "invalid_time", "time", in.GetAtTime(), "from", addr, "err", err)
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+	if round == 0 {
 		r, err = d.beacon.Store().Last()
 	} else {
 		// fetch the correct entry or the next one if not found
-		r, err = d.beacon.Store().Get(in.GetRound())
+		r, err = d.beacon.Store().Get(round)
 	}
 	if err != nil || r == nil {
-		d.log.Debug("public_rand", "unstored_beacon", "round", in.GetRound(), "from", addr)
-		return nil, fmt.Errorf("can't retrieve beacon: %s %s", err, r)
+		d.log.Debug("public_rand", "unstored_beacon", "round", round, "from", addr)
+		return nil, status.Errorf(codes.NotFound, "can't retrieve beacon: %s %s", err, r)
 	}
 	d.log.Info("public_rand", addr, "round", r.Round, "reply", r.String())
 	return beaconToProto(r), nil
@@ -142,12 +157,7 @@ func (d *Drand) PublicRandStream(req *drand.PublicRandRequest, stream drand.Publ
 	// then we can stream from any new rounds
 	// register a callback for the duration of this stream
 	d.callbacks.AddCallback(addr, func(b *beacon.Beacon) {
-		err := stream.Send(&drand.PublicRandResponse{
-			Round:             b.Round,
-			Signature:         b.Signature,
-			PreviousSignature: b.PreviousSig,
-			Randomness:        b.Randomness(),
-		})
+		err := stream.Send(beaconToProto(b))
 		// if connection has a problem, we drop the callback
 		if err != nil {
 			d.callbacks.DelCallback(addr)
@@ -157,6 +167,46 @@ func (d *Drand) PublicRandStream(req *drand.PublicRandRequest, stream drand.Publ
 	return <-done
 }
 
+// PublicRandRange streams in order the beacons of the requested range. It
+// sends at most MaxRangeLength beacons and stops at the first round missing
+// from the store.
+func (d *Drand) PublicRandRange(req *drand.PublicRandRangeRequest, stream drand.Public_PublicRandRangeServer) error {
+	d.state.Lock()
+	b := d.beacon
+	d.state.Unlock()
+	if b == nil {
+		return errors.New("drand: beacon generation not started yet")
+	}
+	from, to := req.GetFrom(), req.GetTo()
+	if from == 0 {
+		return status.Error(codes.InvalidArgument, "drand: range must start after the genesis round")
+	}
+	if to != 0 && to < from {
+		return status.Errorf(codes.InvalidArgument, "drand: invalid range from %d to %d", from, to)
+	}
+	addr := "<unknown>"
+	if peer, ok := peer.FromContext(stream.Context()); ok {
+		addr = peer.Addr.String()
+	}
+	// read the page first so the store is not held while sending
+	var page []*beacon.Beacon
+	b.Store().Cursor(func(c beacon.Cursor) {
+		for bb := c.Seek(from); bb != nil && len(page) < MaxRangeLength; bb = c.Next() {
+			if bb.Round != from+uint64(len(page)) || (to != 0 && bb.Round > to) {
+				break
+			}
+			page = append(page, bb)
+		}
+	})
+	d.log.Debug("public_rand_range", addr, "from", from, "to", to, "reply", len(page))
+	for _, bb := range page {
+		if err := stream.Send(beaconToProto(bb)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // PrivateRand returns an ECIES encrypted random blob of 32 bytes from /dev/urandom
 func (d *Drand) PrivateRand(c context.Context, priv *drand.PrivateRandRequest) (*drand.PrivateRandResponse, error) {
 	if !d.opts.enablePrivate {
@@ -195,6 +245,66 @@ func (d *Drand) Home(c context.Context, in *drand.HomeRequest) (*drand.HomeRespo
 	}, nil
 }
 
+// Health reports that the node is alive.
+func (d *Drand) Health(c context.Context, in *drand.HealthRequest) (*drand.HealthResponse, error) {
+	return &drand.HealthResponse{Status: "OK"}, nil
+}
+
+// Ready reports whether the node serves the round preceding the current round
+// or a later one.
+func (d *Drand) Ready(c context.Context, in *drand.ReadyRequest) (*drand.ReadyResponse, error) {
+	latest, current, err := d.latestRound()
+	if err == nil && latest+1 < current {
+		err = fmt.Errorf("latest round %d is behind current round %d", latest, current)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "not ready: %s", err)
+	}
+	return &drand.ReadyResponse{Status: "OK"}, nil
+}
+
+// Info describes the chain and the lag of the latest round of the node.
+func (d *Drand) Info(c context.Context, in *drand.InfoRequest) (*drand.InfoResponse, error) {
+	d.state.Lock()
+	group := d.group
+	d.state.Unlock()
+	if group == nil {
+		return nil, status.Error(codes.Unavailable, "drand: no dkg group setup yet")
+	}
+	info := &drand.InfoResponse{
+		GroupHash:   group.Hash(),
+		GenesisTime: group.GenesisTime,
+		Period:      uint32(group.Period.Seconds()),
+	}
+	if group.PublicKey != nil {
+		info.PublicKey, _ = group.PublicKey.Key().MarshalBinary()
+	}
+	// the chain is described even before the node produces beacons
+	latest, current, _ := d.latestRound()
+	info.LatestRound = latest
+	if current > latest {
+		info.Lag = current - latest
+	}
+	return info, nil
+}
+
+// latestRound returns the latest round stored and the current round of the
+// chain.
+func (d *Drand) latestRound() (uint64, uint64, error) {
+	d.state.Lock()
+	b, group := d.beacon, d.group
+	d.state.Unlock()
+	if group == nil || b == nil {
+		return 0, 0, errors.New("drand: beacon generation not started yet")
+	}
+	current := beacon.CurrentRound(d.opts.clock.Now().Unix(), group.Period, group.GenesisTime)
+	last, err := b.Store().Last()
+	if err != nil {
+		return 0, current, err
+	}
+	return last.Round, current, nil
+}
+
 // Group replies with the current group of this drand node in a TOML encoded
 // format
 func (d *Drand) Group(ctx context.Context, in *drand.GroupRequest) (*drand.GroupPacket, error) {
@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"io/ioutil"
 	gnet "net"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path"
 	"sync"
@@ -13,6 +15,8 @@ import (
 	"time"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/drand/drand/beacon"
 	"github.com/drand/drand/key"
@@ -20,10 +24,13 @@ import (
 	"github.com/drand/drand/net"
 	"github.com/drand/drand/protobuf/drand"
 	"github.com/drand/drand/test"
+	"github.com/drand/drand/timelock"
 
 	//"github.com/drand/kyber"
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
 	clock "github.com/jonboulle/clockwork"
 	"github.com/kabukky/httpscerts"
+	json "github.com/nikkolasg/hexjson"
 	"github.com/stretchr/testify/require"
 )
 
@@ -634,6 +641,125 @@ func TestDrandPublicRand(t *testing.T) {
 		require.Equal(t, i, resp.Round)
 		fmt.Println("REQUEST ROUND ", i, " GOT ROUND ", resp.Round)
 	}
+
+	// fetch the whole range at once
+	resps, err := client.PublicRandRange(ctx, rootID, &drand.PublicRandRangeRequest{From: initRound, To: max - 1})
+	require.NoError(t, err)
+	require.Len(t, resps, int(max-initRound))
+	for i, resp := range resps {
+		require.Equal(t, initRound+uint64(i), resp.Round)
+	}
+	resps, err = client.PublicRandRange(ctx, rootID, &drand.PublicRandRangeRequest{From: 1})
+	require.NoError(t, err)
+	require.Equal(t, max-1, resps[len(resps)-1].Round)
+	_, err = client.PublicRandRange(ctx, rootID, &drand.PublicRandRangeRequest{From: max, To: 1})
+	require.Error(t, err)
+}
+
+// Test the health, readiness and chain info of a node, on the RPC calls and on
+// their REST gateway
+func TestDrandHealth(t *testing.T) {
+	n := 4
+	thr := key.DefaultThreshold(n)
+	p := 1 * time.Second
+	dt := NewDrandTest2(t, n, thr, p)
+	defer dt.Cleanup()
+	root := dt.nodes[0].drand
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	mux := runtime.NewServeMux(runtime.WithMarshalerOption(runtime.MIMEWildcard, &net.HexJSON{}))
+	require.NoError(t, drand.RegisterPublicHandlerServer(ctx, mux, root))
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	get := func(path string, v interface{}) int {
+		resp, err := http.Get(server.URL + path)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		if resp.StatusCode == http.StatusOK && v != nil {
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(v))
+		}
+		return resp.StatusCode
+	}
+
+	// the node is alive but has no chain yet
+	health, err := root.Health(ctx, new(drand.HealthRequest))
+	require.NoError(t, err)
+	require.Equal(t, "OK", health.Status)
+	require.Equal(t, http.StatusOK, get("/api/health", nil))
+	_, err = root.Ready(ctx, new(drand.ReadyRequest))
+	require.Equal(t, codes.Unavailable, status.Code(err))
+	require.Equal(t, http.StatusServiceUnavailable, get("/api/ready", nil))
+	_, err = root.Info(ctx, new(drand.InfoRequest))
+	require.Equal(t, codes.Unavailable, status.Code(err))
+
+	group := dt.RunDKG()
+	time.Sleep(getSleepDuration())
+	dt.MoveToTime(group.GenesisTime)
+	for i := 0; i < 3; i++ {
+		dt.MoveTime(group.Period)
+	}
+
+	_, err = root.Ready(ctx, new(drand.ReadyRequest))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, get("/api/ready", nil))
+	info := new(drand.InfoResponse)
+	require.Equal(t, http.StatusOK, get("/api/info", info))
+	require.Equal(t, group.Hash(), info.GroupHash)
+	require.Equal(t, group.GenesisTime, info.GenesisTime)
+	require.Equal(t, uint32(group.Period.Seconds()), info.Period)
+	public, err := group.PublicKey.Key().MarshalBinary()
+	require.NoError(t, err)
+	require.Equal(t, public, info.PublicKey)
+	require.NotZero(t, info.LatestRound)
+	require.True(t, info.Lag <= 1, "lag of %d rounds", info.Lag)
+}
+
+// Test that a payload timelock encrypted to a future round can be decrypted
+// with the unchained signature of the beacon produced for that round
+func TestDrandTimelock(t *testing.T) {
+	n := 4
+	thr := key.DefaultThreshold(n)
+	p := 1 * time.Second
+	dt := NewDrandTest2(t, n, thr, p)
+	defer dt.Cleanup()
+	group := dt.RunDKG()
+	time.Sleep(getSleepDuration())
+	root := dt.nodes[0].drand
+	rootID := root.priv.Public
+
+	dt.MoveToTime(group.GenesisTime)
+	dt.MoveTime(group.Period)
+
+	client := net.NewGrpcClientFromCertManager(root.opts.certmanager)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	resp, err := client.PublicRand(ctx, rootID, new(drand.PublicRandRequest))
+	require.NoError(t, err)
+
+	round := resp.Round + 2
+	payload := []byte("revealed at a future round")
+	ciphertext, err := timelock.Encrypt(group.PublicKey.Key(), round, payload)
+	require.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		dt.MoveTime(group.Period)
+	}
+	resp, err = client.PublicRand(ctx, rootID, &drand.PublicRandRequest{Round: round})
+	require.NoError(t, err)
+	require.NotEmpty(t, resp.UnchainedSignature)
+	require.NoError(t, beacon.VerifyBeacon(group.PublicKey.Key(), &beacon.Beacon{
+		PreviousSig:  resp.PreviousSignature,
+		Round:        resp.Round,
+		Signature:    resp.Signature,
+		UnchainedSig: resp.UnchainedSignature,
+	}))
+	decrypted, err := timelock.Decrypt(resp.UnchainedSignature, ciphertext)
+	require.NoError(t, err)
+	require.Equal(t, payload, decrypted)
+	// the chained signature does not decrypt
+	_, err = timelock.Decrypt(resp.Signature, ciphertext)
+	require.Equal(t, timelock.ErrInvalidSignature, err)
 }
 
 // Test if the we can correctly fetch the rounds after a DKG using the
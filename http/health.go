@@ -0,0 +1,107 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/drand/drand/beacon"
+	"github.com/drand/drand/protobuf/drand"
+
+	json "github.com/nikkolasg/hexjson"
+)
+
+// Info describes the chain served and how far the latest round served lags
+// behind the current round. It is served as JSON on the /info endpoint.
+type Info struct {
+	GroupHash   []byte `json:"group_hash"`
+	GenesisTime int64  `json:"genesis_time"`
+	// Period is the period of the chain in seconds.
+	Period      uint32 `json:"period"`
+	PublicKey   []byte `json:"public_key"`
+	LatestRound uint64 `json:"latest_round"`
+	// Lag is the number of rounds between the latest round and the current
+	// round of the chain.
+	Lag uint64 `json:"lag"`
+}
+
+// freshLatest returns the latest round verified and the current round of the
+// chain. The latest round is fetched from the upstreams if none was verified
+// yet or if it is more than one period behind, so probes are cheap while the
+// relay is up to date.
+func (h *handler) freshLatest(ctx context.Context) (uint64, uint64, error) {
+	grp := h.group(ctx)
+	if grp == nil {
+		return 0, 0, fmt.Errorf("group unknown")
+	}
+	current := beacon.CurrentRound(time.Now().Unix(), grp.Period, grp.GenesisTime)
+	if latest := h.latestVerified(); latest != 0 && latest+1 >= current {
+		return latest, current, nil
+	}
+	err := h.do(ctx, func(ctx context.Context, client drand.PublicClient) error {
+		resp, err := client.PublicRand(ctx, &drand.PublicRandRequest{})
+		if err != nil {
+			return err
+		}
+		return h.verify(ctx, resp)
+	})
+	return h.latestVerified(), current, err
+}
+
+// Health reports that the server is alive.
+func (h *handler) Health(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Write([]byte("OK\n"))
+}
+
+// Ready reports whether the server is connected to an upstream and serves
+// the round preceding the current round or a later one.
+func (h *handler) Ready(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Cache-Control", "no-cache")
+	latest, current, err := h.freshLatest(r.Context())
+	if err == nil && (latest == 0 || latest+1 < current) {
+		err = fmt.Errorf("latest round %d is behind current round %d", latest, current)
+	}
+	if err != nil {
+		http.Error(w, fmt.Sprintf("not ready: %s", err), http.StatusServiceUnavailable)
+		h.log.Debug("http_server", "not ready", "client", r.RemoteAddr, "err", err)
+		return
+	}
+	w.Write([]byte("OK\n"))
+}
+
+// Info serves the description of the chain and the lag of the latest round.
+func (h *handler) Info(w http.ResponseWriter, r *http.Request) {
+	grp := h.group(r.Context())
+	if grp == nil {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		h.log.Warn("http_server", "failed to serve info without group", "client", r.RemoteAddr, "req", url.PathEscape(r.URL.Path))
+		return
+	}
+	// the latest round verified is served even if no upstream answers
+	latest, current, _ := h.freshLatest(r.Context())
+	info := Info{
+		GroupHash:   grp.Hash(),
+		GenesisTime: grp.GenesisTime,
+		Period:      uint32(grp.Period.Seconds()),
+		LatestRound: latest,
+	}
+	if grp.PublicKey != nil {
+		info.PublicKey, _ = grp.PublicKey.Key().MarshalBinary()
+	}
+	if current > latest {
+		info.Lag = current - latest
+	}
+
+	data, err := json.Marshal(info)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		h.log.Warn("http_server", "failed to marshal info", "client", r.RemoteAddr, "req", url.PathEscape(r.URL.Path), "err", err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Write(data)
+}
@@ -3,6 +3,7 @@ package http
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"math"
 	"net/http"
@@ -17,105 +18,208 @@ import (
 	"github.com/drand/drand/log"
 	"github.com/drand/drand/protobuf/drand"
 
+	lru "github.com/hashicorp/golang-lru"
 	json "github.com/nikkolasg/hexjson"
 )
 
 var (
 	// Timeout for how long to wait for the drand.PublicClient before timing out
 	reqTimeout = 5 * time.Second
+	// Number of verified beacons kept to be served while no upstream answers
+	cacheSize = 1024
 )
 
 // New creates an HTTP handler for the public Drand API
 func New(ctx context.Context, client drand.PublicClient, logger log.Logger) (http.Handler, error) {
+	return NewWithUpstreams(ctx, []drand.PublicClient{client}, nil, nil, logger)
+}
+
+// NewWithUpstreams creates an HTTP handler for the public Drand API which
+// fails over between the given upstream nodes. Beacons are verified against
+// the key of the group before being served, and the latest ones are cached to
+// keep serving them while no upstream answers. If group is nil, it is fetched
+// from the upstreams.
+//
+// If store is not nil, the verified beacons are kept in it and the rounds it
+// misses are back-filled from the upstreams, so historical rounds are served
+// locally. Its sync status is served on the /status endpoint.
+func NewWithUpstreams(ctx context.Context, clients []drand.PublicClient, group *key.Group, store beacon.Store, logger log.Logger) (http.Handler, error) {
+	if len(clients) == 0 {
+		return nil, errors.New("at least one upstream is required")
+	}
 	if logger == nil {
 		logger = log.DefaultLogger
 	}
+	cache, err := lru.NewARC(cacheSize)
+	if err != nil {
+		return nil, err
+	}
 	handler := handler{
 		timeout:     reqTimeout,
-		client:      client,
-		groupInfo:   nil,
+		upstreams:   newUpstreams(clients),
+		groupInfo:   group,
+		cache:       cache,
+		store:       store,
+		syncCh:      make(chan struct{}, 1),
 		log:         logger,
 		pending:     make([]chan []byte, 0),
 		latestRound: 0,
+		streams:     make(map[chan *drand.PublicRandResponse]struct{}),
 	}
 
 	go handler.Watch(ctx)
+	go handler.checkUpstreams(ctx)
 
 	mux := http.NewServeMux()
-	//TODO: aggregated bulk round responses.
 	mux.HandleFunc("/public/latest", handler.LatestRand)
+	mux.HandleFunc("/public/range", handler.PublicRandRange)
+	mux.HandleFunc("/public/stream", handler.PublicRandStream)
+	mux.HandleFunc("/public/at/", handler.PublicRandAt)
 	mux.HandleFunc("/public/", handler.PublicRand)
 	mux.HandleFunc("/group", handler.Group)
+	mux.HandleFunc("/health", handler.Health)
+	mux.HandleFunc("/ready", handler.Ready)
+	mux.HandleFunc("/info", handler.Info)
+	if store != nil {
+		go handler.syncStore(ctx)
+		mux.HandleFunc("/status", handler.Status)
+	}
 	return mux, nil
 }
 
 type handler struct {
 	timeout   time.Duration
-	client    drand.PublicClient
-	groupInfo *key.Group
+	upstreams *upstreams
 	log       log.Logger
 
+	groupLk   sync.Mutex
+	groupInfo *key.Group
+
+	// verified beacons by round, and the latest one
+	cache    *lru.ARCCache
+	latestLk sync.Mutex
+	latest   *drand.PublicRandResponse
+
+	// local store of the verified beacons and its back-fill, if enabled
+	store       beacon.Store
+	syncCh      chan struct{}
+	syncLk      sync.Mutex
+	syncErr     error
+	historyDone bool
+	syncStarted bool
+	firstRound  uint64
+	syncedRound uint64
+
 	// synchronization for blocking writes until randomness available.
 	pendingLk   sync.RWMutex
 	pending     []chan []byte
 	latestRound uint64
+
+	// streams opened on the stream endpoint
+	streamsLk sync.Mutex
+	streams   map[chan *drand.PublicRandResponse]struct{}
 }
 
+// Watch relays the new beacons of the upstreams to the waiting requests and
+// the streams. It fails over between the upstreams and backs off while none
+// of them streams beacons, until the context is done.
 func (h *handler) Watch(ctx context.Context) {
-RESET:
-	stream, err := h.client.PublicRandStream(context.Background(), &drand.PublicRandRequest{})
-	if err != nil {
-		return
+	// fetch the group meanwhile so the first beacons are verified right away
+	go h.group(ctx)
+	backoff := minWatchBackoff
+	for {
+		if h.watch(ctx) {
+			backoff = minWatchBackoff
+		}
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return
+		}
+		if backoff *= 2; backoff > maxWatchBackoff {
+			backoff = maxWatchBackoff
+		}
 	}
+}
 
-	for {
-		next, err := stream.Recv()
+// watch relays the beacons of the first upstream that streams them, until its
+// stream fails. It returns whether any beacon was received.
+func (h *handler) watch(ctx context.Context) bool {
+	for _, up := range h.upstreams.ordered() {
+		stream, err := up.client.PublicRandStream(ctx, &drand.PublicRandRequest{})
 		if err != nil {
-			h.log.Warn("http_server", "random stream round failed", "err", err)
-			goto RESET
+			// some upstreams may not support streaming
+			h.log.Debug("http_server", "random stream failed to open", "err", err)
+			continue
 		}
-
-		bytes, err := json.Marshal(next)
-
-		h.pendingLk.Lock()
-		if h.latestRound+1 != next.Round && h.latestRound != 0 {
-			// we missed a round, or similar. don't send bad data to peers.
-			h.log.Warn("http_server", "unexpected round for watch", "err", fmt.Sprintf("expected %d, saw %d", h.latestRound+1, next.Round))
-			bytes = []byte{}
+		received := false
+		for {
+			next, err := stream.Recv()
+			if err != nil {
+				if ctx.Err() == nil {
+					h.log.Warn("http_server", "random stream round failed", "err", err)
+				}
+				break
+			}
+			if err := h.verify(ctx, next); err != nil {
+				h.log.Warn("http_server", "invalid beacon from random stream", "round", next.GetRound(), "err", err)
+				continue
+			}
+			received = true
+			h.relay(next)
 		}
-		h.latestRound = next.Round
-		pending := h.pending
-		h.pending = make([]chan []byte, 0)
-		h.pendingLk.Unlock()
-
-		for _, waiter := range pending {
-			waiter <- bytes
+		if received {
+			return true
 		}
-
-		select {
-		case <-ctx.Done():
-			return
-		default:
+		if ctx.Err() != nil {
+			return false
 		}
 	}
+	return false
+}
+
+// relay sends a new beacon to the waiting requests and the streams.
+func (h *handler) relay(next *drand.PublicRandResponse) {
+	bytes, _ := json.Marshal(next)
+
+	h.pendingLk.Lock()
+	if h.latestRound+1 != next.Round && h.latestRound != 0 {
+		// we missed a round, or similar. don't send bad data to peers.
+		h.log.Warn("http_server", "unexpected round for watch", "err", fmt.Sprintf("expected %d, saw %d", h.latestRound+1, next.Round))
+		bytes = []byte{}
+		h.requestSync()
+	}
+	h.latestRound = next.Round
+	pending := h.pending
+	h.pending = make([]chan []byte, 0)
+	h.pendingLk.Unlock()
+
+	for _, waiter := range pending {
+		waiter <- bytes
+	}
+	h.broadcast(next)
 }
 
 func (h *handler) group(ctx context.Context) *key.Group {
+	h.groupLk.Lock()
+	defer h.groupLk.Unlock()
 	if h.groupInfo != nil {
 		return h.groupInfo
 	}
 
-	ctx, cancel := context.WithTimeout(ctx, h.timeout)
-	defer cancel()
-	pkt, err := h.client.Group(ctx, &drand.GroupRequest{})
+	var pkt *drand.GroupPacket
+	err := h.do(ctx, func(ctx context.Context, client drand.PublicClient) error {
+		var err error
+		pkt, err = client.Group(ctx, &drand.GroupRequest{})
+		if err == nil && pkt == nil {
+			err = errors.New("group fetch didn't return group info")
+		}
+		return err
+	})
 	if err != nil {
 		h.log.Warn("msg", "group fetch failed", "err", err)
 		return nil
 	}
-	if pkt == nil {
-		h.log.Warn("msg", "group fetch didn't return group info")
-		return nil
-	}
 	parsedPkt, err := key.GroupFromProto(pkt)
 	if err != nil {
 		h.log.Warn("msg", "invalid group fetch", "err", err)
@@ -129,13 +233,13 @@ func (h *handler) getRand(ctx context.Context, round uint64) ([]byte, error) {
 	// First see if we should get on the synchronized 'wait for next release' bandwagon.
 	block := false
 	h.pendingLk.RLock()
-	block = (h.latestRound+1 == round)
+	block = (h.latestRound != 0 && h.latestRound+1 == round)
 	h.pendingLk.RUnlock()
 	// If so, prepare, and if we're still sync'd, add ourselves to the list of waiters.
 	if block {
 		ch := make(chan []byte)
 		h.pendingLk.Lock()
-		block = (h.latestRound+1 == round)
+		block = (h.latestRound != 0 && h.latestRound+1 == round)
 		if block {
 			h.pending = append(h.pending, ch)
 		}
@@ -160,15 +264,10 @@ func (h *handler) getRand(ctx context.Context, round uint64) ([]byte, error) {
 		}
 	}
 
-	req := drand.PublicRandRequest{Round: round}
-	ctx, cancel := context.WithTimeout(ctx, h.timeout)
-	defer cancel()
-	resp, err := h.client.PublicRand(ctx, &req)
-
+	resp, err := h.publicRand(ctx, round)
 	if err != nil {
 		return nil, err
 	}
-
 	return json.Marshal(resp)
 }
 
@@ -182,6 +281,49 @@ func (h *handler) PublicRand(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	h.servePublicRand(w, r, roundN)
+}
+
+// PublicRandAt serves the randomness of the round that was current at the time
+// given in the path, either as a UNIX timestamp or in RFC 3339 format.
+func (h *handler) PublicRandAt(w http.ResponseWriter, r *http.Request) {
+	at, err := parseTime(strings.Replace(r.URL.Path, "/public/at/", "", 1))
+	if err != nil {
+		http.Error(w, "invalid time: must be a UNIX timestamp or in RFC 3339 format", http.StatusBadRequest)
+		h.log.Warn("http_server", "failed to parse client time", "client", r.RemoteAddr, "req", url.PathEscape(r.URL.Path))
+		return
+	}
+	grp := h.group(r.Context())
+	if grp == nil {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		h.log.Warn("http_server", "failed to resolve time without group", "client", r.RemoteAddr, "req", url.PathEscape(r.URL.Path))
+		return
+	}
+	roundN, err := beacon.RoundAtTime(at.Unix(), time.Now().Unix(), grp.Period, grp.GenesisTime)
+	switch err {
+	case nil:
+	case beacon.ErrBeforeGenesis:
+		http.Error(w, fmt.Sprintf("time is before genesis (%s)", time.Unix(grp.GenesisTime, 0).UTC().Format(time.RFC3339)), http.StatusBadRequest)
+		return
+	case beacon.ErrFutureTime:
+		http.Error(w, "time is in the future", http.StatusNotFound)
+		return
+	default:
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	h.servePublicRand(w, r, roundN)
+}
+
+// parseTime parses a UNIX timestamp in seconds or a time in RFC 3339 format.
+func parseTime(s string) (time.Time, error) {
+	if unix, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return time.Unix(unix, 0), nil
+	}
+	return time.Parse(time.RFC3339, s)
+}
+
+func (h *handler) servePublicRand(w http.ResponseWriter, r *http.Request, roundN uint64) {
 	data, err := h.getRand(r.Context(), roundN)
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
@@ -202,13 +344,52 @@ func (h *handler) PublicRand(w http.ResponseWriter, r *http.Request) {
 	http.ServeContent(w, r, "rand.json", roundExpectedTime, bytes.NewReader(data))
 }
 
-func (h *handler) LatestRand(w http.ResponseWriter, r *http.Request) {
-	req := drand.PublicRandRequest{Round: 0}
-	ctx, cancel := context.WithTimeout(r.Context(), h.timeout)
-	defer cancel()
+// PublicRandRange serves the beacons from round `from` to round `to` included
+// as a JSON array, ordered by round. If `to` is not specified or is above the
+// last beacon, the beacons are served up to the last one. The node bounds the
+// number of beacons per request, so clients should request the remaining
+// rounds starting after the last round received.
+func (h *handler) PublicRandRange(w http.ResponseWriter, r *http.Request) {
+	var from, to uint64
+	var err error
+	query := r.URL.Query()
+	from, err = strconv.ParseUint(query.Get("from"), 10, 64)
+	if err == nil && query.Get("to") != "" {
+		to, err = strconv.ParseUint(query.Get("to"), 10, 64)
+	}
+	if err != nil || from == 0 || (to != 0 && to < from) {
+		http.Error(w, "invalid range: from must be a positive round lower than to", http.StatusBadRequest)
+		h.log.Warn("http_server", "failed to parse client range", "client", r.RemoteAddr, "req", url.PathEscape(r.URL.String()))
+		return
+	}
 
-	resp, err := h.client.PublicRand(ctx, &req)
+	resps, err := h.publicRandRange(r.Context(), from, to)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		h.log.Warn("http_server", "failed to get randomness range", "client", r.RemoteAddr, "req", url.PathEscape(r.URL.String()), "err", err)
+		return
+	}
+	if resps == nil {
+		resps = make([]*drand.PublicRandResponse, 0)
+	}
 
+	data, err := json.Marshal(resps)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		h.log.Warn("http_server", "failed to marshal randomness range", "client", r.RemoteAddr, "req", url.PathEscape(r.URL.String()), "err", err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if to != 0 && len(resps) > 0 && resps[len(resps)-1].Round == to {
+		// the full range is served so it will never change
+		w.Header().Set("Cache-Control", "public, max-age=604800, immutable")
+		w.Header().Set("Expires", time.Now().Add(7*24*time.Hour).Format(http.TimeFormat))
+	}
+	w.Write(data)
+}
+
+func (h *handler) LatestRand(w http.ResponseWriter, r *http.Request) {
+	resp, err := h.publicRand(r.Context(), 0)
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		h.log.Warn("http_server", "failed to get randomness", "client", r.RemoteAddr, "req", url.PathEscape(r.URL.Path), "err", err)
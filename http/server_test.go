@@ -1,24 +1,35 @@
 package http
 
 import (
+	"bufio"
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"net"
 	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/drand/drand/beacon"
+	"github.com/drand/drand/key"
 	"github.com/drand/drand/protobuf/drand"
 	"github.com/drand/drand/test/mock"
 
 	json "github.com/nikkolasg/hexjson"
+	"golang.org/x/net/websocket"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func withClient(t *testing.T) drand.PublicClient {
 	t.Helper()
 
-	l, _ := mock.NewMockGRPCPublicServer(":0")
+	l, _ := mock.NewMockGRPCPublicServer(":0", false)
 	lAddr := l.Addr()
 	go l.Start()
 
@@ -81,6 +92,255 @@ func TestHTTPRelay(t *testing.T) {
 	}
 }
 
+// switchClient fails all requests while it is down.
+type switchClient struct {
+	drand.PublicClient
+	down int32
+}
+
+var errDown = errors.New("upstream down")
+
+func (s *switchClient) PublicRand(ctx context.Context, in *drand.PublicRandRequest, opts ...grpc.CallOption) (*drand.PublicRandResponse, error) {
+	if atomic.LoadInt32(&s.down) == 1 {
+		return nil, errDown
+	}
+	return s.PublicClient.PublicRand(ctx, in, opts...)
+}
+
+func (s *switchClient) PublicRandStream(ctx context.Context, in *drand.PublicRandRequest, opts ...grpc.CallOption) (drand.Public_PublicRandStreamClient, error) {
+	if atomic.LoadInt32(&s.down) == 1 {
+		return nil, errDown
+	}
+	return s.PublicClient.PublicRandStream(ctx, in, opts...)
+}
+
+func (s *switchClient) PublicRandRange(ctx context.Context, in *drand.PublicRandRangeRequest, opts ...grpc.CallOption) (drand.Public_PublicRandRangeClient, error) {
+	if atomic.LoadInt32(&s.down) == 1 {
+		return nil, errDown
+	}
+	return s.PublicClient.PublicRandRange(ctx, in, opts...)
+}
+
+func (s *switchClient) Group(ctx context.Context, in *drand.GroupRequest, opts ...grpc.CallOption) (*drand.GroupPacket, error) {
+	if atomic.LoadInt32(&s.down) == 1 {
+		return nil, errDown
+	}
+	return s.PublicClient.Group(ctx, in, opts...)
+}
+
+func TestHTTPRelayFailover(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	client := withClient(t)
+	down := &switchClient{PublicClient: client, down: 1}
+	up := &switchClient{PublicClient: client}
+
+	handler, err := NewWithUpstreams(ctx, []drand.PublicClient{down, up}, nil, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	listener, err := net.Listen("tcp", ":0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := http.Server{Handler: handler}
+	go server.Serve(listener)
+	defer server.Shutdown(ctx)
+	addr := listener.Addr().String()
+
+	getRound := func(path string) (uint64, int) {
+		t.Helper()
+		resp, err := http.Get(fmt.Sprintf("http://%s%s", addr, path))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return 0, resp.StatusCode
+		}
+		body := make(map[string]interface{})
+		if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+		return uint64(body["round"].(float64)), resp.StatusCode
+	}
+
+	// the first upstream is down
+	latest, status := getRound("/public/latest")
+	if status != http.StatusOK {
+		t.Fatalf("relay should fail over to the second upstream, got status %d", status)
+	}
+
+	// all upstreams are down
+	atomic.StoreInt32(&up.down, 1)
+	if round, status := getRound(fmt.Sprintf("/public/%d", latest)); status != http.StatusOK || round != latest {
+		t.Fatalf("cached round %d should be served, got round %d with status %d", latest, round, status)
+	}
+	if round, status := getRound("/public/latest"); status != http.StatusOK || round < latest {
+		t.Fatalf("latest cached round should be served, got round %d with status %d", round, status)
+	}
+	if _, status := getRound(fmt.Sprintf("/public/%d", latest+100)); status != http.StatusInternalServerError {
+		t.Fatalf("uncached round should fail, got status %d", status)
+	}
+}
+
+// refusingClient counts the requests for randomness and, if refuse is set,
+// refuses the ones for a given round as a node which does not have it. It
+// does not stream beacons, so the mock server only moves to its next round
+// when asked for randomness.
+type refusingClient struct {
+	drand.PublicClient
+	refuse bool
+	calls  int32
+}
+
+func (r *refusingClient) PublicRand(ctx context.Context, in *drand.PublicRandRequest, opts ...grpc.CallOption) (*drand.PublicRandResponse, error) {
+	atomic.AddInt32(&r.calls, 1)
+	if r.refuse && in.GetRound() != 0 {
+		return nil, status.Errorf(codes.NotFound, "round %d not found", in.GetRound())
+	}
+	return r.PublicClient.PublicRand(ctx, in, opts...)
+}
+
+func (r *refusingClient) PublicRandStream(ctx context.Context, in *drand.PublicRandRequest, opts ...grpc.CallOption) (drand.Public_PublicRandStreamClient, error) {
+	return nil, errors.New("streaming unsupported")
+}
+
+func TestHTTPRelayRequestError(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	client := withClient(t)
+	first := &refusingClient{PublicClient: client, refuse: true}
+	second := &refusingClient{PublicClient: client}
+
+	handler, err := NewWithUpstreams(ctx, []drand.PublicClient{first, second}, nil, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	listener, err := net.Listen("tcp", ":0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := http.Server{Handler: handler}
+	go server.Serve(listener)
+	defer server.Shutdown(ctx)
+	addr := listener.Addr().String()
+
+	getRound := func(path string) (uint64, int) {
+		t.Helper()
+		resp, err := http.Get(fmt.Sprintf("http://%s%s", addr, path))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return 0, resp.StatusCode
+		}
+		body := make(map[string]interface{})
+		if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+		return uint64(body["round"].(float64)), resp.StatusCode
+	}
+
+	// the mock server serves the round following the one it served last
+	last, err := client.PublicRand(ctx, &drand.PublicRandRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	next := last.GetRound() + 1
+	if round, status := getRound(fmt.Sprintf("/public/%d", next)); status != http.StatusOK || round != next {
+		t.Fatalf("second upstream should serve round %d, got round %d with status %d", next, round, status)
+	}
+	if calls := atomic.LoadInt32(&second.calls); calls != 1 {
+		t.Fatalf("relay should fail over to the second upstream once, got %d requests", calls)
+	}
+
+	// the first upstream misses a round but is still healthy so it serves
+	// the next request
+	if _, status := getRound("/public/latest"); status != http.StatusOK {
+		t.Fatalf("latest round should be served, got status %d", status)
+	}
+	if calls := atomic.LoadInt32(&first.calls); calls != 2 {
+		t.Fatalf("first upstream should stay healthy and serve both requests, got %d requests", calls)
+	}
+	if calls := atomic.LoadInt32(&second.calls); calls != 1 {
+		t.Fatalf("second upstream should not be used again, got %d requests", calls)
+	}
+}
+
+// shiftedClient serves the beacon of the round following the one requested.
+type shiftedClient struct {
+	drand.PublicClient
+}
+
+func (s *shiftedClient) PublicRand(ctx context.Context, in *drand.PublicRandRequest, opts ...grpc.CallOption) (*drand.PublicRandResponse, error) {
+	return s.PublicClient.PublicRand(ctx, &drand.PublicRandRequest{Round: in.GetRound() + 1}, opts...)
+}
+
+func TestHTTPRelayWrongRound(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	client := withClient(t)
+	shifted := &shiftedClient{PublicClient: client}
+
+	handler, err := NewWithUpstreams(ctx, []drand.PublicClient{shifted}, nil, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	listener, err := net.Listen("tcp", ":0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := http.Server{Handler: handler}
+	go server.Serve(listener)
+	defer server.Shutdown(ctx)
+
+	resp, err := http.Get(fmt.Sprintf("http://%s/public/5", listener.Addr().String()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode == http.StatusOK {
+		t.Fatal("beacon of another round should not be served")
+	}
+}
+
+func TestHTTPRelayVerification(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	pkt, err := withClient(t).Group(ctx, &drand.GroupRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	group, err := key.GroupFromProto(pkt)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the upstream serves beacons of another group
+	handler, err := NewWithUpstreams(ctx, []drand.PublicClient{withClient(t)}, group, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	listener, err := net.Listen("tcp", ":0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := http.Server{Handler: handler}
+	go server.Serve(listener)
+	defer server.Shutdown(ctx)
+
+	resp, err := http.Get(fmt.Sprintf("http://%s/public/latest", listener.Addr().String()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusInternalServerError {
+		t.Fatalf("beacons of another group should not be served, got status %d", resp.StatusCode)
+	}
+}
+
 func TestHTTPWaiting(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -118,3 +378,367 @@ func TestHTTPWaiting(t *testing.T) {
 		t.Fatalf("unexpected timing to receive %v", body)
 	}
 }
+
+func TestHTTPRelayAt(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	client := withClient(t)
+
+	handler, err := New(ctx, client, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	listener, err := net.Listen("tcp", ":0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := http.Server{Handler: handler}
+	go server.Serve(listener)
+	defer server.Shutdown(ctx)
+	time.Sleep(100 * time.Millisecond)
+
+	now := time.Now()
+	statuses := map[string]int{
+		now.Format(time.RFC3339):                      http.StatusOK,
+		fmt.Sprintf("%d", now.Unix()):                 http.StatusOK,
+		"yesterday":                                   http.StatusBadRequest,
+		fmt.Sprintf("%d", now.Add(-time.Hour).Unix()): http.StatusBadRequest,
+		now.Add(time.Hour).Format(time.RFC3339):       http.StatusNotFound,
+	}
+	for at, status := range statuses {
+		resp, err := http.Get(fmt.Sprintf("http://%s/public/at/%s", listener.Addr().String(), at))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.StatusCode != status {
+			t.Fatalf("expected status %d for time %s, got %d", status, at, resp.StatusCode)
+		}
+		if status != http.StatusOK {
+			continue
+		}
+		body := make(map[string]interface{})
+		if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+		if _, ok := body["signature"]; !ok {
+			t.Fatal("expected signature in random response.")
+		}
+	}
+}
+
+func TestHTTPRelayRange(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	client := withClient(t)
+
+	handler, err := New(ctx, &refusingClient{PublicClient: client}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	listener, err := net.Listen("tcp", ":0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := http.Server{Handler: handler}
+	go server.Serve(listener)
+	defer server.Shutdown(ctx)
+	time.Sleep(100 * time.Millisecond)
+
+	// the mock server only sends the round following the one it served last
+	last, err := client.PublicRand(ctx, &drand.PublicRandRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := http.Get(fmt.Sprintf("http://%s/public/range?from=1", listener.Addr().String()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode == http.StatusOK {
+		t.Fatal("range skipping the first rounds requested should not be served")
+	}
+
+	resp, err = http.Get(fmt.Sprintf("http://%s/public/range?from=%d", listener.Addr().String(), last.GetRound()+1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var body []map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatal(err)
+	}
+	if len(body) != 1 {
+		t.Fatalf("expected one beacon in range, got %d", len(body))
+	}
+	if _, ok := body[0]["signature"]; !ok {
+		t.Fatal("expected signature in range response.")
+	}
+
+	for _, query := range []string{"", "from=0", "from=5&to=2", "from=a"} {
+		resp, err := http.Get(fmt.Sprintf("http://%s/public/range?%s", listener.Addr().String(), query))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.StatusCode != http.StatusBadRequest {
+			t.Fatalf("expected invalid range %q to be refused, got status %d", query, resp.StatusCode)
+		}
+	}
+}
+
+func TestHTTPRelayStream(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	client := withClient(t)
+
+	handler, err := New(ctx, client, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	listener, err := net.Listen("tcp", ":0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := http.Server{Handler: handler}
+	go server.Serve(listener)
+	defer server.Shutdown(ctx)
+	addr := listener.Addr().String()
+
+	readEvent := func(r *bufio.Reader) (uint64, map[string]interface{}) {
+		t.Helper()
+		var id uint64
+		body := make(map[string]interface{})
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				t.Fatal(err)
+			}
+			line = strings.TrimSuffix(line, "\n")
+			if line == "" {
+				return id, body
+			}
+			if strings.HasPrefix(line, "id: ") {
+				if id, err = strconv.ParseUint(strings.TrimPrefix(line, "id: "), 10, 64); err != nil {
+					t.Fatal(err)
+				}
+			} else if strings.HasPrefix(line, "data: ") {
+				if err := json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &body); err != nil {
+					t.Fatal(err)
+				}
+			}
+		}
+	}
+
+	resp, err := http.Get(fmt.Sprintf("http://%s/public/stream", addr))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Header.Get("Content-Type") != "text/event-stream" {
+		t.Fatalf("unexpected content type %q", resp.Header.Get("Content-Type"))
+	}
+	events := bufio.NewReader(resp.Body)
+	first, body := readEvent(events)
+	if _, ok := body["signature"]; !ok || body["round"].(float64) != float64(first) {
+		t.Fatalf("unexpected first event %d: %v", first, body)
+	}
+	// the mock server streams a new round every second
+	second, _ := readEvent(events)
+	if second <= first {
+		t.Fatalf("expected a round after %d, got %d", first, second)
+	}
+	resp.Body.Close()
+
+	// resume after a given round
+	req, _ := http.NewRequest("GET", fmt.Sprintf("http://%s/public/stream", addr), nil)
+	req.Header.Set("Last-Event-ID", strconv.FormatUint(second, 10))
+	resp, err = http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if next, _ := readEvent(bufio.NewReader(resp.Body)); next <= second {
+		t.Fatalf("expected a round after %d, got %d", second, next)
+	}
+	resp.Body.Close()
+
+	resp, err = http.Get(fmt.Sprintf("http://%s/public/stream?last-event-id=abc", addr))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("invalid last event id should be rejected, got %d", resp.StatusCode)
+	}
+
+	// websocket mode
+	ws, err := websocket.Dial(fmt.Sprintf("ws://%s/public/stream", addr), "", "http://localhost/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ws.Close()
+	var msg string
+	if err := websocket.Message.Receive(ws, &msg); err != nil {
+		t.Fatal(err)
+	}
+	body = make(map[string]interface{})
+	if err := json.Unmarshal([]byte(msg), &body); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := body["round"]; !ok {
+		t.Fatalf("expected round in websocket message: %v", body)
+	}
+}
+
+func TestHTTPRelayStore(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	up := &switchClient{PublicClient: withClient(t)}
+	store, err := beacon.NewMemoryStore(100)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	handler, err := NewWithUpstreams(ctx, []drand.PublicClient{up}, nil, store, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	listener, err := net.Listen("tcp", ":0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := http.Server{Handler: handler}
+	go server.Serve(listener)
+	defer server.Shutdown(ctx)
+
+	var status SyncStatus
+	for i := 0; !status.Synced; i++ {
+		if i == 50 {
+			t.Fatalf("store should get synced, status: %+v", status)
+		}
+		time.Sleep(100 * time.Millisecond)
+		resp, err := http.Get(fmt.Sprintf("http://%s/status", listener.Addr().String()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = json.NewDecoder(resp.Body).Decode(&status)
+		resp.Body.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if status.Stored == 0 || status.SyncedRound < status.FirstRound || status.LastRound < status.SyncedRound {
+		t.Fatalf("inconsistent status %+v", status)
+	}
+
+	// a relay restarted on the same store serves its rounds without upstream
+	atomic.StoreInt32(&up.down, 1)
+	pkt, err := up.PublicClient.Group(ctx, &drand.GroupRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	group, err := key.GroupFromProto(pkt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	restarted, err := NewWithUpstreams(ctx, []drand.PublicClient{up}, group, store, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	restartedListener, err := net.Listen("tcp", ":0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	restartedServer := http.Server{Handler: restarted}
+	go restartedServer.Serve(restartedListener)
+	defer restartedServer.Shutdown(ctx)
+
+	resp, err := http.Get(fmt.Sprintf("http://%s/public/%d", restartedListener.Addr().String(), status.SyncedRound))
+	if err != nil {
+		t.Fatal(err)
+	}
+	body := make(map[string]interface{})
+	err = json.NewDecoder(resp.Body).Decode(&body)
+	resp.Body.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if body["round"].(float64) != float64(status.SyncedRound) {
+		t.Fatalf("stored round %d should be served, got %v", status.SyncedRound, body)
+	}
+}
+
+func TestHTTPRelayHealth(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	client := withClient(t)
+	pkt, err := client.Group(ctx, &drand.GroupRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	group, err := key.GroupFromProto(pkt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the same chain, if it had started long ago
+	stale, err := key.GroupFromProto(pkt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stale.GenesisTime -= int64(10000 * stale.Period.Seconds())
+
+	for _, grp := range []*key.Group{group, stale} {
+		handler, err := NewWithUpstreams(ctx, []drand.PublicClient{client}, grp, nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		listener, err := net.Listen("tcp", ":0")
+		if err != nil {
+			t.Fatal(err)
+		}
+		server := http.Server{Handler: handler}
+		go server.Serve(listener)
+		defer server.Shutdown(ctx)
+		addr := listener.Addr().String()
+
+		resp, err := http.Get(fmt.Sprintf("http://%s/health", addr))
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("health should be ok, got status %d", resp.StatusCode)
+		}
+
+		resp, err = http.Get(fmt.Sprintf("http://%s/ready", addr))
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if grp == group && resp.StatusCode != http.StatusOK {
+			t.Fatalf("relay should be ready, got status %d", resp.StatusCode)
+		}
+		if grp == stale && resp.StatusCode != http.StatusServiceUnavailable {
+			t.Fatalf("relay behind the current round should not be ready, got status %d", resp.StatusCode)
+		}
+
+		resp, err = http.Get(fmt.Sprintf("http://%s/info", addr))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var info Info
+		err = json.NewDecoder(resp.Body).Decode(&info)
+		resp.Body.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(info.GroupHash, grp.Hash()) || info.GenesisTime != grp.GenesisTime || info.Period != 60 {
+			t.Fatalf("unexpected chain info %+v", info)
+		}
+		if len(info.PublicKey) == 0 || info.LatestRound == 0 {
+			t.Fatalf("info should have the public key and the latest round: %+v", info)
+		}
+		if (grp == group && info.Lag != 0) || (grp == stale && info.Lag == 0) {
+			t.Fatalf("unexpected lag %d", info.Lag)
+		}
+	}
+}
@@ -0,0 +1,244 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/drand/drand/beacon"
+	"github.com/drand/drand/protobuf/drand"
+
+	json "github.com/nikkolasg/hexjson"
+)
+
+// maxRangeLength bounds the number of beacons served from the local store for
+// a single range request, as the nodes do.
+const maxRangeLength = 1000
+
+var (
+	// How often the local store is back-filled with the rounds it misses
+	syncPeriod = time.Minute
+)
+
+// SyncStatus is the status of the local store of the relay, served as JSON on
+// the status endpoint.
+type SyncStatus struct {
+	// Stored is the number of beacons in the store.
+	Stored int `json:"stored"`
+	// FirstRound is the lowest round stored.
+	FirstRound uint64 `json:"first_round"`
+	// SyncedRound is the round up to which all the rounds since FirstRound
+	// are stored.
+	SyncedRound uint64 `json:"synced_round"`
+	// LastRound is the highest round stored.
+	LastRound uint64 `json:"last_round"`
+	// LatestRound is the latest round received from the upstreams.
+	LatestRound uint64 `json:"latest_round"`
+	// Synced is true when all the rounds up to LatestRound are stored.
+	Synced bool `json:"synced"`
+	// Error is the error of the last back-fill, if it failed.
+	Error string `json:"error,omitempty"`
+}
+
+// save stores a verified beacon in the local store, if any.
+func (h *handler) save(resp *drand.PublicRandResponse) {
+	if h.store == nil {
+		return
+	}
+	b := &beacon.Beacon{
+		PreviousSig:  resp.GetPreviousSignature(),
+		Round:        resp.GetRound(),
+		Signature:    resp.GetSignature(),
+		UnchainedSig: resp.GetUnchainedSignature(),
+	}
+	if err := h.store.Put(b); err != nil {
+		h.log.Warn("http_server", "failed to store beacon", "round", b.Round, "err", err)
+	}
+}
+
+// stored returns the beacon of the round from the local store, if any.
+func (h *handler) stored(round uint64) *drand.PublicRandResponse {
+	if h.store == nil {
+		return nil
+	}
+	b, err := h.store.Get(round)
+	if err != nil {
+		return nil
+	}
+	return &drand.PublicRandResponse{
+		Round:              b.Round,
+		PreviousSignature:  b.PreviousSig,
+		Signature:          b.Signature,
+		Randomness:         b.Randomness(),
+		UnchainedSignature: b.UnchainedSig,
+	}
+}
+
+// local returns the beacon of the round from the cache or the local store, if
+// any.
+func (h *handler) local(round uint64) *drand.PublicRandResponse {
+	if resp := h.cached(round); resp != nil {
+		return resp
+	}
+	return h.stored(round)
+}
+
+// localRange returns the beacons following each other from round from which
+// are available locally, up to round to, or without bound if to is zero.
+func (h *handler) localRange(from, to uint64) []*drand.PublicRandResponse {
+	var resps []*drand.PublicRandResponse
+	for round := from; (to == 0 || round <= to) && len(resps) < maxRangeLength; round++ {
+		resp := h.local(round)
+		if resp == nil {
+			break
+		}
+		resps = append(resps, resp)
+	}
+	return resps
+}
+
+// latestVerified returns the latest round verified, or zero.
+func (h *handler) latestVerified() uint64 {
+	h.latestLk.Lock()
+	defer h.latestLk.Unlock()
+	if h.latest == nil {
+		return 0
+	}
+	return h.latest.Round
+}
+
+// syncStore back-fills the local store with the rounds it misses,
+// periodically or when a round is missed by the Watch loop, until the context
+// is done.
+func (h *handler) syncStore(ctx context.Context) {
+	ticker := time.NewTicker(syncPeriod)
+	defer ticker.Stop()
+	for {
+		err := h.backfill(ctx)
+		h.syncLk.Lock()
+		h.syncErr = err
+		h.syncLk.Unlock()
+		if err != nil && ctx.Err() == nil {
+			h.log.Warn("http_server", "store back-fill failed", "err", err)
+		}
+		select {
+		case <-ticker.C:
+		case <-h.syncCh:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// requestSync triggers a back-fill of the local store.
+func (h *handler) requestSync() {
+	if h.store == nil {
+		return
+	}
+	select {
+	case h.syncCh <- struct{}{}:
+	default:
+	}
+}
+
+// backfill fetches the rounds missing from the local store up to the latest
+// round. The rounds the store starts with are fetched once from the beginning
+// of the chain, as far back as the upstreams serve it.
+func (h *handler) backfill(ctx context.Context) error {
+	h.syncLk.Lock()
+	historyDone := h.historyDone
+	h.syncLk.Unlock()
+	if !historyDone {
+		first, _, ok := h.syncedRounds()
+		if !ok || first > 1 {
+			to := uint64(0)
+			if ok {
+				to = first - 1
+			}
+			if _, err := h.publicRandRange(ctx, 1, to); err != nil {
+				return err
+			}
+		}
+		h.syncLk.Lock()
+		h.historyDone = true
+		h.syncLk.Unlock()
+	}
+
+	target := h.latestVerified()
+	if target == 0 {
+		resp, err := h.publicRand(ctx, 0)
+		if err != nil {
+			return err
+		}
+		target = resp.Round
+	}
+	for {
+		_, synced, ok := h.syncedRounds()
+		if !ok || synced >= target {
+			return nil
+		}
+		if _, err := h.publicRandRange(ctx, synced+1, target); err != nil {
+			return err
+		}
+		if _, next, _ := h.syncedRounds(); next == synced {
+			return fmt.Errorf("upstreams don't serve round %d", synced+1)
+		}
+	}
+}
+
+// syncedRounds returns the lowest round stored and the round up to which all
+// the following rounds are stored. It returns false if the store is empty.
+func (h *handler) syncedRounds() (uint64, uint64, bool) {
+	h.syncLk.Lock()
+	defer h.syncLk.Unlock()
+	var first *beacon.Beacon
+	h.store.Cursor(func(c beacon.Cursor) {
+		first = c.First()
+	})
+	if first == nil {
+		return 0, 0, false
+	}
+	if !h.syncStarted || first.Round != h.firstRound {
+		// rounds were stored before the first one, start over from there
+		h.firstRound = first.Round
+		h.syncedRound = first.Round
+		h.syncStarted = true
+	}
+	for {
+		if _, err := h.store.Get(h.syncedRound + 1); err != nil {
+			break
+		}
+		h.syncedRound++
+	}
+	return h.firstRound, h.syncedRound, true
+}
+
+// Status serves the sync status of the local store.
+func (h *handler) Status(w http.ResponseWriter, r *http.Request) {
+	status := SyncStatus{
+		Stored:      h.store.Len(),
+		LatestRound: h.latestVerified(),
+	}
+	status.FirstRound, status.SyncedRound, _ = h.syncedRounds()
+	if last, err := h.store.Last(); err == nil {
+		status.LastRound = last.Round
+	}
+	status.Synced = status.LatestRound != 0 && status.SyncedRound >= status.LatestRound
+	h.syncLk.Lock()
+	if h.syncErr != nil {
+		status.Error = h.syncErr.Error()
+	}
+	h.syncLk.Unlock()
+
+	data, err := json.Marshal(status)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		h.log.Warn("http_server", "failed to marshal status", "client", r.RemoteAddr, "req", url.PathEscape(r.URL.Path), "err", err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Write(data)
+}
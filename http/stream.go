@@ -0,0 +1,174 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/drand/drand/protobuf/drand"
+
+	json "github.com/nikkolasg/hexjson"
+	"golang.org/x/net/websocket"
+)
+
+// streamBuffer is the number of beacons buffered for each stream. A stream
+// falling further behind is closed, the client can resume it with the
+// Last-Event-ID header.
+const streamBuffer = 10
+
+// lastEventIDParam is the query parameter that can be used instead of the
+// Last-Event-ID header, e.g. by WebSocket clients which can't set headers.
+const lastEventIDParam = "last-event-id"
+
+var errStreamTooSlow = errors.New("stream closed: client too slow")
+
+// subscribe registers a new stream fed by the Watch loop.
+func (h *handler) subscribe() chan *drand.PublicRandResponse {
+	ch := make(chan *drand.PublicRandResponse, streamBuffer)
+	h.streamsLk.Lock()
+	defer h.streamsLk.Unlock()
+	h.streams[ch] = struct{}{}
+	return ch
+}
+
+// unsubscribe removes the stream if it has not been closed already.
+func (h *handler) unsubscribe(ch chan *drand.PublicRandResponse) {
+	h.streamsLk.Lock()
+	defer h.streamsLk.Unlock()
+	if _, ok := h.streams[ch]; ok {
+		delete(h.streams, ch)
+		close(ch)
+	}
+}
+
+// broadcast sends the new beacon to all streams, closing the ones that are
+// too slow to keep up.
+func (h *handler) broadcast(resp *drand.PublicRandResponse) {
+	h.streamsLk.Lock()
+	defer h.streamsLk.Unlock()
+	for ch := range h.streams {
+		select {
+		case ch <- resp:
+		default:
+			delete(h.streams, ch)
+			close(ch)
+		}
+	}
+}
+
+// stream sends to the client the beacons following the round last, or the
+// latest beacon if last is zero, and then every new beacon, until the context
+// is done or sending fails.
+func (h *handler) stream(ctx context.Context, last uint64, send func(*drand.PublicRandResponse) error) error {
+	// subscribe first so no beacon is missed while catching up
+	ch := h.subscribe()
+	defer h.unsubscribe(ch)
+
+	if last == 0 {
+		resp, err := h.publicRand(ctx, 0)
+		if err != nil {
+			return err
+		}
+		if err := send(resp); err != nil {
+			return err
+		}
+		last = resp.Round
+	} else {
+		// catch up with the rounds missed by the client
+		for {
+			resps, err := h.publicRandRange(ctx, last+1, 0)
+			if err != nil {
+				return err
+			}
+			for _, resp := range resps {
+				if err := send(resp); err != nil {
+					return err
+				}
+				last = resp.Round
+			}
+			if len(resps) == 0 {
+				break
+			}
+		}
+	}
+
+	for {
+		select {
+		case resp, ok := <-ch:
+			if !ok {
+				return errStreamTooSlow
+			}
+			if resp.Round <= last {
+				continue
+			}
+			if err := send(resp); err != nil {
+				return err
+			}
+			last = resp.Round
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// PublicRandStream streams the beacons as they are produced, either as
+// Server-Sent Events or over a WebSocket if the client asks for an upgrade.
+// Each event carries the round as identifier so clients can resume the stream
+// with the Last-Event-ID header, or the last-event-id query parameter.
+func (h *handler) PublicRandStream(w http.ResponseWriter, r *http.Request) {
+	var last uint64
+	lastID := r.Header.Get("Last-Event-ID")
+	if lastID == "" {
+		lastID = r.URL.Query().Get(lastEventIDParam)
+	}
+	if lastID != "" {
+		var err error
+		last, err = strconv.ParseUint(lastID, 10, 64)
+		if err != nil {
+			http.Error(w, "invalid last event id: must be a round number", http.StatusBadRequest)
+			h.log.Warn("http_server", "failed to parse last event id", "client", r.RemoteAddr, "req", url.PathEscape(r.URL.Path))
+			return
+		}
+	}
+
+	if strings.EqualFold(r.Header.Get("Upgrade"), "websocket") {
+		websocket.Server{Handler: func(ws *websocket.Conn) {
+			err := h.stream(r.Context(), last, func(resp *drand.PublicRandResponse) error {
+				data, err := json.Marshal(resp)
+				if err != nil {
+					return err
+				}
+				return websocket.Message.Send(ws, string(data))
+			})
+			h.log.Debug("http_server", "websocket stream closed", "client", r.RemoteAddr, "err", err)
+		}}.ServeHTTP(w, r)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		w.WriteHeader(http.StatusInternalServerError)
+		h.log.Warn("http_server", "streaming unsupported by response writer", "client", r.RemoteAddr)
+		return
+	}
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+	err := h.stream(r.Context(), last, func(resp *drand.PublicRandResponse) error {
+		data, err := json.Marshal(resp)
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(w, "id: %d\ndata: %s\n\n", resp.Round, data); err != nil {
+			return err
+		}
+		flusher.Flush()
+		return nil
+	})
+	h.log.Debug("http_server", "event stream closed", "client", r.RemoteAddr, "err", err)
+}
@@ -0,0 +1,269 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/drand/drand/beacon"
+	"github.com/drand/drand/protobuf/drand"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	// How often the unhealthy upstreams are checked
+	healthCheckPeriod = 30 * time.Second
+	// Bounds of the delay before reopening the stream of new beacons
+	minWatchBackoff = time.Second
+	maxWatchBackoff = time.Minute
+)
+
+// upstream is a drand node the relay fetches beacons from.
+type upstream struct {
+	client  drand.PublicClient
+	healthy bool
+}
+
+// upstreams keeps track of the health of the nodes the relay fetches beacons
+// from, to fail over between them.
+type upstreams struct {
+	sync.Mutex
+	list []*upstream
+}
+
+func newUpstreams(clients []drand.PublicClient) *upstreams {
+	u := &upstreams{}
+	for _, c := range clients {
+		u.list = append(u.list, &upstream{client: c, healthy: true})
+	}
+	return u
+}
+
+// ordered returns the healthy upstreams followed by the unhealthy ones, in the
+// order they were given.
+func (u *upstreams) ordered() []*upstream {
+	u.Lock()
+	defer u.Unlock()
+	ordered := make([]*upstream, 0, len(u.list))
+	for _, up := range u.list {
+		if up.healthy {
+			ordered = append(ordered, up)
+		}
+	}
+	for _, up := range u.list {
+		if !up.healthy {
+			ordered = append(ordered, up)
+		}
+	}
+	return ordered
+}
+
+// unhealthy returns the unhealthy upstreams.
+func (u *upstreams) unhealthy() []*upstream {
+	u.Lock()
+	defer u.Unlock()
+	var unhealthy []*upstream
+	for _, up := range u.list {
+		if !up.healthy {
+			unhealthy = append(unhealthy, up)
+		}
+	}
+	return unhealthy
+}
+
+func (u *upstreams) setHealthy(up *upstream, healthy bool) {
+	u.Lock()
+	defer u.Unlock()
+	up.healthy = healthy
+}
+
+// isRequestError returns whether the upstream refused the request itself as
+// invalid, so no other upstream would serve it.
+func isRequestError(err error) bool {
+	return status.Code(err) == codes.InvalidArgument
+}
+
+// isMissingError returns whether the upstream answered that it does not have
+// the rounds requested, e.g. because it lags behind or pruned them.
+func isMissingError(err error) bool {
+	switch status.Code(err) {
+	case codes.NotFound, codes.OutOfRange:
+		return true
+	}
+	return false
+}
+
+// do calls fn with the upstreams, healthy ones first, until it succeeds. The
+// upstreams for which it fails, to answer or with an invalid beacon, are
+// marked unhealthy until they pass a health check or succeed again. An
+// upstream missing the rounds requested is answering, so it stays healthy
+// while the next upstreams are tried. An invalid request is not retried.
+func (h *handler) do(ctx context.Context, fn func(ctx context.Context, client drand.PublicClient) error) error {
+	err := errors.New("no upstream")
+	for _, up := range h.upstreams.ordered() {
+		tctx, cancel := context.WithTimeout(ctx, h.timeout)
+		err = fn(tctx, up.client)
+		cancel()
+		if err == nil {
+			h.upstreams.setHealthy(up, true)
+			return nil
+		}
+		if ctx.Err() != nil || isRequestError(err) {
+			return err
+		}
+		if isMissingError(err) {
+			h.log.Debug("http_server", "upstream misses rounds", "err", err)
+			continue
+		}
+		h.log.Warn("http_server", "upstream failed", "err", err)
+		h.upstreams.setHealthy(up, false)
+	}
+	return err
+}
+
+// checkUpstreams periodically checks whether the unhealthy upstreams serve
+// valid beacons again, until the context is done.
+func (h *handler) checkUpstreams(ctx context.Context) {
+	ticker := time.NewTicker(healthCheckPeriod)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+		for _, up := range h.upstreams.unhealthy() {
+			tctx, cancel := context.WithTimeout(ctx, h.timeout)
+			resp, err := up.client.PublicRand(tctx, &drand.PublicRandRequest{})
+			if err == nil {
+				err = h.verify(tctx, resp)
+			}
+			cancel()
+			if err == nil {
+				h.log.Info("http_server", "upstream healthy again")
+				h.upstreams.setHealthy(up, true)
+			}
+		}
+	}
+}
+
+// verify checks the beacon is signed by the group, sets its randomness and
+// caches it.
+func (h *handler) verify(ctx context.Context, resp *drand.PublicRandResponse) error {
+	grp := h.group(ctx)
+	if grp == nil {
+		return errors.New("group unknown, can't verify beacon")
+	}
+	b := &beacon.Beacon{
+		PreviousSig:  resp.GetPreviousSignature(),
+		Round:        resp.GetRound(),
+		Signature:    resp.GetSignature(),
+		UnchainedSig: resp.GetUnchainedSignature(),
+	}
+	if err := beacon.VerifyBeacon(grp.PublicKey.Key(), b); err != nil {
+		return err
+	}
+	randomness := b.Randomness()
+	if len(resp.Randomness) > 0 && !bytes.Equal(resp.Randomness, randomness) {
+		return errors.New("randomness does not match the signature")
+	}
+	resp.Randomness = randomness
+	h.cache.Add(resp.Round, resp)
+	h.save(resp)
+	h.latestLk.Lock()
+	if h.latest == nil || h.latest.Round < resp.Round {
+		h.latest = resp
+	}
+	h.latestLk.Unlock()
+	return nil
+}
+
+// cached returns the cached beacon of the round, if any.
+func (h *handler) cached(round uint64) *drand.PublicRandResponse {
+	if v, ok := h.cache.Get(round); ok {
+		return v.(*drand.PublicRandResponse)
+	}
+	return nil
+}
+
+// publicRand returns the verified beacon of the round, or the latest beacon
+// if round is 0, from the cache, the local store or the upstreams. The latest
+// beacon verified is served if no upstream answers.
+func (h *handler) publicRand(ctx context.Context, round uint64) (*drand.PublicRandResponse, error) {
+	if round != 0 {
+		if resp := h.local(round); resp != nil {
+			return resp, nil
+		}
+	}
+	var resp *drand.PublicRandResponse
+	err := h.do(ctx, func(ctx context.Context, client drand.PublicClient) error {
+		var err error
+		resp, err = client.PublicRand(ctx, &drand.PublicRandRequest{Round: round})
+		if err != nil {
+			return err
+		}
+		if round != 0 && resp.GetRound() != round {
+			return fmt.Errorf("upstream sent round %d instead of round %d", resp.GetRound(), round)
+		}
+		return h.verify(ctx, resp)
+	})
+	if err != nil && round == 0 {
+		h.latestLk.Lock()
+		defer h.latestLk.Unlock()
+		if h.latest != nil {
+			h.log.Warn("http_server", "serving cached latest beacon", "round", h.latest.Round, "err", err)
+			return h.latest, nil
+		}
+	}
+	return resp, err
+}
+
+// publicRandRange returns the verified beacons of the range, following each
+// other from round from, as served by one request to an upstream. The beacons
+// available locally are served instead if they cover the range, a full page or
+// up to the latest round, or if no upstream answers.
+func (h *handler) publicRandRange(ctx context.Context, from, to uint64) ([]*drand.PublicRandResponse, error) {
+	local := h.localRange(from, to)
+	if n := len(local); n > 0 {
+		last := local[n-1].Round
+		latest := h.latestVerified()
+		if (to != 0 && last == to) || n == maxRangeLength || (latest != 0 && last >= latest) {
+			return local, nil
+		}
+	}
+	var resps []*drand.PublicRandResponse
+	err := h.do(ctx, func(ctx context.Context, client drand.PublicClient) error {
+		resps = resps[:0]
+		stream, err := client.PublicRandRange(ctx, &drand.PublicRandRangeRequest{From: from, To: to})
+		if err != nil {
+			return err
+		}
+		for {
+			resp, err := stream.Recv()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			next := from + uint64(len(resps))
+			if resp.GetRound() != next || (to != 0 && next > to) {
+				return fmt.Errorf("upstream sent round %d of range from %d to %d instead of round %d", resp.GetRound(), from, to, next)
+			}
+			if err := h.verify(ctx, resp); err != nil {
+				return err
+			}
+			resps = append(resps, resp)
+		}
+	})
+	if err != nil && len(local) > 0 {
+		h.log.Warn("http_server", "serving local beacons of range", "from", from, "to", local[len(local)-1].Round, "err", err)
+		return local, nil
+	}
+	return resps, err
+}
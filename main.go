@@ -6,6 +6,7 @@ import (
 	"bufio"
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path"
@@ -14,6 +15,7 @@ import (
 	"runtime"
 	"strconv"
 	"strings"
+	"time"
 
 	gonet "net"
 
@@ -214,6 +216,48 @@ var enablePrivateRand = &cli.BoolFlag{
 	Usage: "Enables the private randomness feature on the daemon. By default, this feature is disabled.",
 }
 
+var dbEngineFlag = &cli.StringFlag{
+	Name: "db-engine",
+	Usage: fmt.Sprintf("Database engine used to store the beacons: %q (default), %q or %q. "+
+		"The %q engine only keeps the most recent beacons and loses them on restart.",
+		core.BoltEngine, core.SQLiteEngine, core.MemoryEngine, core.MemoryEngine),
+}
+
+var keepRoundsFlag = &cli.IntFlag{
+	Name:  "keep-rounds",
+	Usage: "Only keep the given number of most recent beacons in the database. By default, the whole chain is kept.",
+}
+
+var keepForFlag = &cli.StringFlag{
+	Name:  "keep-for",
+	Usage: "Only keep the beacons generated during the given duration in the database, e.g. 720h. By default, the whole chain is kept.",
+}
+
+var truncateFlag = &cli.BoolFlag{
+	Name:  "truncate",
+	Usage: "Delete all beacons stored after the last valid round of the chain.",
+}
+
+var syncFlag = &cli.BoolFlag{
+	Name:  "sync",
+	Usage: "Fetch the missing beacons up to the current round from the nodes of the group once the chain is truncated.",
+}
+
+var fromRoundFlag = &cli.IntFlag{
+	Name:  "from",
+	Usage: "First round to export.",
+}
+
+var toRoundFlag = &cli.IntFlag{
+	Name:  "to",
+	Usage: "Last round to export. If not specified, the chain is exported up to its head.",
+}
+
+var archiveOutFlag = &cli.StringFlag{
+	Name:  "out",
+	Usage: "save the archive into the given file instead of stdout",
+}
+
 var hashOnly = &cli.BoolFlag{
 	Name:  "hash-only",
 	Usage: "Only print the hash of the group file",
@@ -235,7 +279,8 @@ func main() {
 			Usage: "Start the drand daemon.",
 			Flags: toArray(folderFlag, tlsCertFlag, tlsKeyFlag,
 				insecureFlag, controlFlag, privListenFlag, pubListenFlag, metricsFlag,
-				certsDirFlag, pushFlag, verboseFlag, enablePrivateRand),
+				certsDirFlag, pushFlag, verboseFlag, enablePrivateRand, dbEngineFlag,
+				keepRoundsFlag, keepForFlag),
 			Action: func(c *cli.Context) error {
 				banner()
 				return startCmd(c)
@@ -356,11 +401,50 @@ func main() {
 				{
 					Name:  "del-beacon",
 					Usage: "Delete all beacons from the given `ROUND` number until the head of the chain. You MUST restart the daemon after that command.",
-					Flags: toArray(folderFlag),
+					Flags: toArray(folderFlag, dbEngineFlag),
 					Action: func(c *cli.Context) error {
 						return deleteBeaconCmd(c)
 					},
 				},
+				{
+					Name: "verify-chain",
+					Usage: "Verifies that the beacons stored in the database form a valid chain and reports " +
+						"any gap, fork, invalid or corrupted beacon. The daemon MUST be stopped while running that command.",
+					ArgsUsage: "[group.toml ...] are the groups that produced the chain before the current one, if any resharing happened.",
+					Flags:     toArray(folderFlag, dbEngineFlag, truncateFlag, syncFlag, insecureFlag, certsDirFlag),
+					Action: func(c *cli.Context) error {
+						return verifyChainCmd(c)
+					},
+				},
+				{
+					Name: "export",
+					Usage: "Exports a range of the chain into a checksummed archive that can be imported by " +
+						"another node or used to seed a client cache.",
+					Flags: toArray(folderFlag, dbEngineFlag, fromRoundFlag, toRoundFlag, archiveOutFlag),
+					Action: func(c *cli.Context) error {
+						return exportCmd(c)
+					},
+				},
+				{
+					Name: "import",
+					Usage: "Verifies and imports the beacons of the given archive into the database. " +
+						"The daemon MUST be stopped while running that command.",
+					ArgsUsage: "<archive> [group.toml ...] are the archive to import and the groups that produced " +
+						"the chain before the current one, if any resharing happened.",
+					Flags: toArray(folderFlag, dbEngineFlag),
+					Action: func(c *cli.Context) error {
+						return importCmd(c)
+					},
+				},
+				{
+					Name: "migrate-db",
+					Usage: "Converts the beacons stored in the JSON format used by previous versions " +
+						"to the compact binary format. The daemon MUST be stopped while running that command.",
+					Flags: toArray(folderFlag),
+					Action: func(c *cli.Context) error {
+						return migrateDBCmd(c)
+					},
+				},
 			},
 		},
 		{
@@ -661,9 +745,9 @@ func deleteBeaconCmd(c *cli.Context) error {
 		return fmt.Errorf("given round not valid: %d", sr)
 	}
 	startRound := uint64(sr)
-	store, err := beacon.NewBoltStore(conf.DBFolder(), conf.BoltOptions())
+	store, err := core.NewStore(conf)
 	if err != nil {
-		return fmt.Errorf("invalid bolt store creation: %s", err)
+		return fmt.Errorf("invalid store creation: %s", err)
 	}
 	defer store.Close()
 	lastBeacon, err := store.Last()
@@ -688,6 +772,139 @@ func deleteBeaconCmd(c *cli.Context) error {
 	return nil
 }
 
+// verifyChainCmd checks the validity of the stored chain and optionally
+// truncates and syncs it again from the other nodes
+func verifyChainCmd(c *cli.Context) error {
+	conf := contextToConfig(c)
+	groups, err := loadChainGroups(conf, c.Args().Slice())
+	if err != nil {
+		return err
+	}
+	group := groups[0]
+	store, err := core.NewStore(conf)
+	if err != nil {
+		return fmt.Errorf("invalid store creation: %s", err)
+	}
+	defer store.Close()
+	report, err := beacon.VerifyChain(store, groups...)
+	if err != nil {
+		return err
+	}
+	for _, fault := range report.Faults {
+		fmt.Printf("- %s\n", fault)
+	}
+	fmt.Printf("drand: checked %d beacons up to round %d, chain valid up to round %d\n", report.Checked, report.Head, report.LastValid)
+	if report.Valid() || !c.Bool(truncateFlag.Name) {
+		if !report.Valid() {
+			return fmt.Errorf("invalid chain: %d faults found", len(report.Faults))
+		}
+		return nil
+	}
+	if err := report.Truncate(store); err != nil {
+		return err
+	}
+	fmt.Printf("drand: chain truncated to round %d\n", report.LastValid)
+	if !c.Bool(syncFlag.Name) {
+		return nil
+	}
+	client := net.NewGrpcClientFromCertManager(conf.Certs())
+	current := beacon.CurrentRound(time.Now().Unix(), group.Period, group.GenesisTime)
+	last, err := beacon.SyncStore(context.Background(), conf.Logger(), store, client, current, groups...)
+	fmt.Printf("drand: chain synced up to round %d\n", last)
+	return err
+}
+
+// loadChainGroups returns the current group of the node followed by the groups
+// stored at the given paths
+func loadChainGroups(conf *core.Config, paths []string) ([]*key.Group, error) {
+	fs := key.NewFileStore(conf.ConfigFolder())
+	group, err := fs.LoadGroup()
+	if err != nil {
+		return nil, fmt.Errorf("can't load group: %s", err)
+	}
+	groups := []*key.Group{group}
+	for _, groupPath := range paths {
+		g := new(key.Group)
+		if err := key.Load(groupPath, g); err != nil {
+			return nil, fmt.Errorf("can't load group %s: %s", groupPath, err)
+		}
+		groups = append(groups, g)
+	}
+	return groups, nil
+}
+
+// exportCmd writes a range of the chain into an archive
+func exportCmd(c *cli.Context) error {
+	conf := contextToConfig(c)
+	group, err := key.NewFileStore(conf.ConfigFolder()).LoadGroup()
+	if err != nil {
+		return fmt.Errorf("can't load group: %s", err)
+	}
+	store, err := core.NewStore(conf)
+	if err != nil {
+		return fmt.Errorf("invalid store creation: %s", err)
+	}
+	defer store.Close()
+	out := os.Stdout
+	if c.IsSet(archiveOutFlag.Name) {
+		out, err = os.Create(c.String(archiveOutFlag.Name))
+		if err != nil {
+			return fmt.Errorf("can't create archive: %s", err)
+		}
+		defer out.Close()
+	}
+	from, to := uint64(c.Int(fromRoundFlag.Name)), uint64(c.Int(toRoundFlag.Name))
+	n, err := beacon.ExportChain(out, store, group.Hash(), from, to)
+	if err != nil {
+		return fmt.Errorf("error exporting chain after %d beacons: %s", n, err)
+	}
+	if c.IsSet(archiveOutFlag.Name) {
+		fmt.Printf("drand: exported %d beacons\n", n)
+	}
+	return nil
+}
+
+// importCmd verifies and saves the beacons of an archive into the database
+func importCmd(c *cli.Context) error {
+	if !c.Args().Present() {
+		return errors.New("missing archive path")
+	}
+	conf := contextToConfig(c)
+	groups, err := loadChainGroups(conf, c.Args().Tail())
+	if err != nil {
+		return err
+	}
+	archive, err := os.Open(c.Args().First())
+	if err != nil {
+		return fmt.Errorf("can't open archive: %s", err)
+	}
+	defer archive.Close()
+	fs.CreateSecureFolder(conf.DBFolder())
+	store, err := core.NewStore(conf)
+	if err != nil {
+		return fmt.Errorf("invalid store creation: %s", err)
+	}
+	defer store.Close()
+	n, err := beacon.ImportChain(archive, store, groups...)
+	if err != nil {
+		return fmt.Errorf("error importing chain after %d beacons: %s", n, err)
+	}
+	fmt.Printf("drand: imported %d beacons\n", n)
+	return nil
+}
+
+// migrateDBCmd converts all legacy JSON-encoded beacons of the database to the
+// binary record format
+func migrateDBCmd(c *cli.Context) error {
+	conf := contextToConfig(c)
+	n, err := beacon.MigrateBoltStore(conf.DBFolder(), conf.BoltOptions())
+	if err != nil {
+		return fmt.Errorf("error migrating database after %d beacons: %s", n, err)
+	}
+	fmt.Printf("drand: converted %d beacons to the binary format\n", n)
+	return nil
+}
+
 func toArray(flags ...cli.Flag) []cli.Flag {
 	return flags
 }
@@ -756,6 +973,24 @@ func contextToConfig(c *cli.Context) *core.Config {
 	if c.Bool(enablePrivateRand.Name) {
 		opts = append(opts, core.WithPrivateRandomness())
 	}
+	if c.IsSet(dbEngineFlag.Name) {
+		opts = append(opts, core.WithDBEngine(c.String(dbEngineFlag.Name)))
+	}
+	if c.IsSet(keepRoundsFlag.Name) || c.IsSet(keepForFlag.Name) {
+		var period time.Duration
+		if c.IsSet(keepForFlag.Name) {
+			var err error
+			period, err = time.ParseDuration(c.String(keepForFlag.Name))
+			if err != nil {
+				fatal("drand: invalid keep-for duration: %s", err)
+			}
+		}
+		rounds := c.Int(keepRoundsFlag.Name)
+		if rounds < 0 {
+			fatal("drand: keep-rounds must be positive")
+		}
+		opts = append(opts, core.WithRetention(uint64(rounds), period))
+	}
 	conf := core.NewConfig(opts...)
 	return conf
 }
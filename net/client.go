@@ -32,6 +32,7 @@ type ProtocolClient interface {
 type PublicClient interface {
 	PublicRandStream(ctx context.Context, p Peer, in *drand.PublicRandRequest, opts ...CallOption) (chan *drand.PublicRandResponse, error)
 	PublicRand(ctx context.Context, p Peer, in *drand.PublicRandRequest) (*drand.PublicRandResponse, error)
+	PublicRandRange(ctx context.Context, p Peer, in *drand.PublicRandRangeRequest) ([]*drand.PublicRandResponse, error)
 	PrivateRand(ctx context.Context, p Peer, in *drand.PrivateRandRequest) (*drand.PrivateRandResponse, error)
 	DistKey(ctx context.Context, p Peer, in *drand.DistKeyRequest) (*drand.DistKeyResponse, error)
 	Group(ctx context.Context, p Peer, in *drand.GroupRequest) (*drand.GroupPacket, error)
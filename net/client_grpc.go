@@ -122,6 +122,33 @@ func (g *grpcClient) PublicRandStream(ctx context.Context, p Peer, in *drand.Pub
 	return outCh, nil
 }
 
+// PublicRandRange returns the beacons of the range sent by the peer in reply to
+// a single request. The peer may send less beacons than requested.
+func (g *grpcClient) PublicRandRange(ctx context.Context, p Peer, in *drand.PublicRandRangeRequest) ([]*drand.PublicRandResponse, error) {
+	c, err := g.conn(p)
+	if err != nil {
+		return nil, err
+	}
+	client := drand.NewPublicClient(c)
+	ctx, cancel := g.getTimeoutContext(ctx)
+	defer cancel()
+	stream, err := client.PublicRandRange(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	var resps []*drand.PublicRandResponse
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			return resps, nil
+		}
+		if err != nil {
+			return resps, err
+		}
+		resps = append(resps, resp)
+	}
+}
+
 func (g *grpcClient) PrivateRand(ctx context.Context, p Peer, in *drand.PrivateRandRequest) (*drand.PrivateRandResponse, error) {
 	var resp *drand.PrivateRandResponse
 	c, err := g.conn(p)
@@ -15,6 +15,11 @@ func (s *EmptyServer) PublicRandStream(*drand.PublicRandRequest, drand.Public_Pu
 	return nil
 }
 
+// PublicRandRange ...
+func (s *EmptyServer) PublicRandRange(*drand.PublicRandRangeRequest, drand.Public_PublicRandRangeServer) error {
+	return nil
+}
+
 // PublicRand ...
 func (s *EmptyServer) PublicRand(context.Context, *drand.PublicRandRequest) (*drand.PublicRandResponse, error) {
 	return nil, nil
@@ -40,6 +45,21 @@ func (s *EmptyServer) Home(context.Context, *drand.HomeRequest) (*drand.HomeResp
 	return nil, nil
 }
 
+// Health ...
+func (s *EmptyServer) Health(context.Context, *drand.HealthRequest) (*drand.HealthResponse, error) {
+	return nil, nil
+}
+
+// Ready ...
+func (s *EmptyServer) Ready(context.Context, *drand.ReadyRequest) (*drand.ReadyResponse, error) {
+	return nil, nil
+}
+
+// Info ...
+func (s *EmptyServer) Info(context.Context, *drand.InfoRequest) (*drand.InfoResponse, error) {
+	return nil, nil
+}
+
 // FreshDKG ...
 func (s *EmptyServer) SignalDKGParticipant(context.Context, *drand.SignalDKGPacket) (*drand.Empty, error) {
 	return nil, nil
@@ -30,7 +30,11 @@ const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 type PublicRandRequest struct {
 	// round uniquely identifies a beacon. If round == 0 (or unspecified), then
 	// the response will contain the last.
-	Round                uint64   `protobuf:"varint,1,opt,name=round,proto3" json:"round,omitempty"`
+	Round uint64 `protobuf:"varint,1,opt,name=round,proto3" json:"round,omitempty"`
+	// at_time is a UNIX timestamp in seconds. If set, the response contains
+	// the beacon of the round that was current at that time. It can not be
+	// set together with round.
+	AtTime               int64    `protobuf:"varint,2,opt,name=at_time,json=atTime,proto3" json:"at_time,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -68,6 +72,65 @@ func (m *PublicRandRequest) GetRound() uint64 {
 	return 0
 }
 
+func (m *PublicRandRequest) GetAtTime() int64 {
+	if m != nil {
+		return m.AtTime
+	}
+	return 0
+}
+
+// PublicRandRangeRequest requests the beacons from round from to round to
+// included.
+type PublicRandRangeRequest struct {
+	// from is the first round requested. It must be strictly positive.
+	From uint64 `protobuf:"varint,1,opt,name=from,proto3" json:"from,omitempty"`
+	// to is the last round requested. If to == 0 (or unspecified), the beacons
+	// are sent up to the last one.
+	To                   uint64   `protobuf:"varint,2,opt,name=to,proto3" json:"to,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PublicRandRangeRequest) Reset()         { *m = PublicRandRangeRequest{} }
+func (m *PublicRandRangeRequest) String() string { return proto.CompactTextString(m) }
+func (*PublicRandRangeRequest) ProtoMessage()    {}
+func (*PublicRandRangeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c0cff3fc81cf7d79, []int{1}
+}
+
+func (m *PublicRandRangeRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PublicRandRangeRequest.Unmarshal(m, b)
+}
+func (m *PublicRandRangeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PublicRandRangeRequest.Marshal(b, m, deterministic)
+}
+func (m *PublicRandRangeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PublicRandRangeRequest.Merge(m, src)
+}
+func (m *PublicRandRangeRequest) XXX_Size() int {
+	return xxx_messageInfo_PublicRandRangeRequest.Size(m)
+}
+func (m *PublicRandRangeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PublicRandRangeRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PublicRandRangeRequest proto.InternalMessageInfo
+
+func (m *PublicRandRangeRequest) GetFrom() uint64 {
+	if m != nil {
+		return m.From
+	}
+	return 0
+}
+
+func (m *PublicRandRangeRequest) GetTo() uint64 {
+	if m != nil {
+		return m.To
+	}
+	return 0
+}
+
 // PublicRandResponse holds a signature which is the random value. It can be
 // verified thanks to the distributed public key of the nodes that have ran the
 // DKG protocol and is unbiasable. The randomness can be verified using the BLS
@@ -78,7 +141,11 @@ type PublicRandResponse struct {
 	PreviousSignature []byte `protobuf:"bytes,3,opt,name=previous_signature,json=previousSignature,proto3" json:"previous_signature,omitempty"`
 	// randomness is simply there to demonstrate - it is the hash of the
 	// signature. It should be computed locally.
-	Randomness           []byte   `protobuf:"bytes,4,opt,name=randomness,proto3" json:"randomness,omitempty"`
+	Randomness []byte `protobuf:"bytes,4,opt,name=randomness,proto3" json:"randomness,omitempty"`
+	// unchained_signature is the signature over the hash of the round alone,
+	// so it can be used to decrypt payloads encrypted to the round in advance.
+	// It is only set for beacons produced with it.
+	UnchainedSignature   []byte   `protobuf:"bytes,5,opt,name=unchained_signature,json=unchainedSignature,proto3" json:"unchained_signature,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -88,7 +155,7 @@ func (m *PublicRandResponse) Reset()         { *m = PublicRandResponse{} }
 func (m *PublicRandResponse) String() string { return proto.CompactTextString(m) }
 func (*PublicRandResponse) ProtoMessage()    {}
 func (*PublicRandResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_c0cff3fc81cf7d79, []int{1}
+	return fileDescriptor_c0cff3fc81cf7d79, []int{2}
 }
 
 func (m *PublicRandResponse) XXX_Unmarshal(b []byte) error {
@@ -137,6 +204,13 @@ func (m *PublicRandResponse) GetRandomness() []byte {
 	return nil
 }
 
+func (m *PublicRandResponse) GetUnchainedSignature() []byte {
+	if m != nil {
+		return m.UnchainedSignature
+	}
+	return nil
+}
+
 // PrivateRandRequest is the message to send when requesting a private random
 // value.
 type PrivateRandRequest struct {
@@ -153,7 +227,7 @@ func (m *PrivateRandRequest) Reset()         { *m = PrivateRandRequest{} }
 func (m *PrivateRandRequest) String() string { return proto.CompactTextString(m) }
 func (*PrivateRandRequest) ProtoMessage()    {}
 func (*PrivateRandRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_c0cff3fc81cf7d79, []int{2}
+	return fileDescriptor_c0cff3fc81cf7d79, []int{3}
 }
 
 func (m *PrivateRandRequest) XXX_Unmarshal(b []byte) error {
@@ -195,7 +269,7 @@ func (m *PrivateRandResponse) Reset()         { *m = PrivateRandResponse{} }
 func (m *PrivateRandResponse) String() string { return proto.CompactTextString(m) }
 func (*PrivateRandResponse) ProtoMessage()    {}
 func (*PrivateRandResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_c0cff3fc81cf7d79, []int{3}
+	return fileDescriptor_c0cff3fc81cf7d79, []int{4}
 }
 
 func (m *PrivateRandResponse) XXX_Unmarshal(b []byte) error {
@@ -234,7 +308,7 @@ func (m *DistKeyRequest) Reset()         { *m = DistKeyRequest{} }
 func (m *DistKeyRequest) String() string { return proto.CompactTextString(m) }
 func (*DistKeyRequest) ProtoMessage()    {}
 func (*DistKeyRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_c0cff3fc81cf7d79, []int{4}
+	return fileDescriptor_c0cff3fc81cf7d79, []int{5}
 }
 
 func (m *DistKeyRequest) XXX_Unmarshal(b []byte) error {
@@ -266,7 +340,7 @@ func (m *DistKeyResponse) Reset()         { *m = DistKeyResponse{} }
 func (m *DistKeyResponse) String() string { return proto.CompactTextString(m) }
 func (*DistKeyResponse) ProtoMessage()    {}
 func (*DistKeyResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_c0cff3fc81cf7d79, []int{5}
+	return fileDescriptor_c0cff3fc81cf7d79, []int{6}
 }
 
 func (m *DistKeyResponse) XXX_Unmarshal(b []byte) error {
@@ -304,7 +378,7 @@ func (m *HomeRequest) Reset()         { *m = HomeRequest{} }
 func (m *HomeRequest) String() string { return proto.CompactTextString(m) }
 func (*HomeRequest) ProtoMessage()    {}
 func (*HomeRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_c0cff3fc81cf7d79, []int{6}
+	return fileDescriptor_c0cff3fc81cf7d79, []int{7}
 }
 
 func (m *HomeRequest) XXX_Unmarshal(b []byte) error {
@@ -336,7 +410,7 @@ func (m *HomeResponse) Reset()         { *m = HomeResponse{} }
 func (m *HomeResponse) String() string { return proto.CompactTextString(m) }
 func (*HomeResponse) ProtoMessage()    {}
 func (*HomeResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_c0cff3fc81cf7d79, []int{7}
+	return fileDescriptor_c0cff3fc81cf7d79, []int{8}
 }
 
 func (m *HomeResponse) XXX_Unmarshal(b []byte) error {
@@ -364,8 +438,264 @@ func (m *HomeResponse) GetStatus() string {
 	return ""
 }
 
+type HealthRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *HealthRequest) Reset()         { *m = HealthRequest{} }
+func (m *HealthRequest) String() string { return proto.CompactTextString(m) }
+func (*HealthRequest) ProtoMessage()    {}
+func (*HealthRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c0cff3fc81cf7d79, []int{9}
+}
+
+func (m *HealthRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HealthRequest.Unmarshal(m, b)
+}
+func (m *HealthRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HealthRequest.Marshal(b, m, deterministic)
+}
+func (m *HealthRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HealthRequest.Merge(m, src)
+}
+func (m *HealthRequest) XXX_Size() int {
+	return xxx_messageInfo_HealthRequest.Size(m)
+}
+func (m *HealthRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_HealthRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HealthRequest proto.InternalMessageInfo
+
+type HealthResponse struct {
+	Status               string   `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *HealthResponse) Reset()         { *m = HealthResponse{} }
+func (m *HealthResponse) String() string { return proto.CompactTextString(m) }
+func (*HealthResponse) ProtoMessage()    {}
+func (*HealthResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c0cff3fc81cf7d79, []int{10}
+}
+
+func (m *HealthResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HealthResponse.Unmarshal(m, b)
+}
+func (m *HealthResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HealthResponse.Marshal(b, m, deterministic)
+}
+func (m *HealthResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HealthResponse.Merge(m, src)
+}
+func (m *HealthResponse) XXX_Size() int {
+	return xxx_messageInfo_HealthResponse.Size(m)
+}
+func (m *HealthResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_HealthResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HealthResponse proto.InternalMessageInfo
+
+func (m *HealthResponse) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+type ReadyRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReadyRequest) Reset()         { *m = ReadyRequest{} }
+func (m *ReadyRequest) String() string { return proto.CompactTextString(m) }
+func (*ReadyRequest) ProtoMessage()    {}
+func (*ReadyRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c0cff3fc81cf7d79, []int{11}
+}
+
+func (m *ReadyRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReadyRequest.Unmarshal(m, b)
+}
+func (m *ReadyRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReadyRequest.Marshal(b, m, deterministic)
+}
+func (m *ReadyRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReadyRequest.Merge(m, src)
+}
+func (m *ReadyRequest) XXX_Size() int {
+	return xxx_messageInfo_ReadyRequest.Size(m)
+}
+func (m *ReadyRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReadyRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReadyRequest proto.InternalMessageInfo
+
+type ReadyResponse struct {
+	Status               string   `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReadyResponse) Reset()         { *m = ReadyResponse{} }
+func (m *ReadyResponse) String() string { return proto.CompactTextString(m) }
+func (*ReadyResponse) ProtoMessage()    {}
+func (*ReadyResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c0cff3fc81cf7d79, []int{12}
+}
+
+func (m *ReadyResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReadyResponse.Unmarshal(m, b)
+}
+func (m *ReadyResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReadyResponse.Marshal(b, m, deterministic)
+}
+func (m *ReadyResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReadyResponse.Merge(m, src)
+}
+func (m *ReadyResponse) XXX_Size() int {
+	return xxx_messageInfo_ReadyResponse.Size(m)
+}
+func (m *ReadyResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReadyResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReadyResponse proto.InternalMessageInfo
+
+func (m *ReadyResponse) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+type InfoRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *InfoRequest) Reset()         { *m = InfoRequest{} }
+func (m *InfoRequest) String() string { return proto.CompactTextString(m) }
+func (*InfoRequest) ProtoMessage()    {}
+func (*InfoRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c0cff3fc81cf7d79, []int{13}
+}
+
+func (m *InfoRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_InfoRequest.Unmarshal(m, b)
+}
+func (m *InfoRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_InfoRequest.Marshal(b, m, deterministic)
+}
+func (m *InfoRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InfoRequest.Merge(m, src)
+}
+func (m *InfoRequest) XXX_Size() int {
+	return xxx_messageInfo_InfoRequest.Size(m)
+}
+func (m *InfoRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_InfoRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_InfoRequest proto.InternalMessageInfo
+
+// InfoResponse describes the chain, as served on the /info endpoint of the
+// HTTP relays.
+type InfoResponse struct {
+	GroupHash   []byte `protobuf:"bytes,1,opt,name=group_hash,json=groupHash,proto3" json:"group_hash,omitempty"`
+	GenesisTime int64  `protobuf:"varint,2,opt,name=genesis_time,json=genesisTime,proto3" json:"genesis_time,omitempty"`
+	// period is the period of the chain in seconds.
+	Period      uint32 `protobuf:"varint,3,opt,name=period,proto3" json:"period,omitempty"`
+	PublicKey   []byte `protobuf:"bytes,4,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+	LatestRound uint64 `protobuf:"varint,5,opt,name=latest_round,json=latestRound,proto3" json:"latest_round,omitempty"`
+	// lag is the number of rounds between the latest round and the current
+	// round of the chain.
+	Lag                  uint64   `protobuf:"varint,6,opt,name=lag,proto3" json:"lag,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *InfoResponse) Reset()         { *m = InfoResponse{} }
+func (m *InfoResponse) String() string { return proto.CompactTextString(m) }
+func (*InfoResponse) ProtoMessage()    {}
+func (*InfoResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c0cff3fc81cf7d79, []int{14}
+}
+
+func (m *InfoResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_InfoResponse.Unmarshal(m, b)
+}
+func (m *InfoResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_InfoResponse.Marshal(b, m, deterministic)
+}
+func (m *InfoResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InfoResponse.Merge(m, src)
+}
+func (m *InfoResponse) XXX_Size() int {
+	return xxx_messageInfo_InfoResponse.Size(m)
+}
+func (m *InfoResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_InfoResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_InfoResponse proto.InternalMessageInfo
+
+func (m *InfoResponse) GetGroupHash() []byte {
+	if m != nil {
+		return m.GroupHash
+	}
+	return nil
+}
+
+func (m *InfoResponse) GetGenesisTime() int64 {
+	if m != nil {
+		return m.GenesisTime
+	}
+	return 0
+}
+
+func (m *InfoResponse) GetPeriod() uint32 {
+	if m != nil {
+		return m.Period
+	}
+	return 0
+}
+
+func (m *InfoResponse) GetPublicKey() []byte {
+	if m != nil {
+		return m.PublicKey
+	}
+	return nil
+}
+
+func (m *InfoResponse) GetLatestRound() uint64 {
+	if m != nil {
+		return m.LatestRound
+	}
+	return 0
+}
+
+func (m *InfoResponse) GetLag() uint64 {
+	if m != nil {
+		return m.Lag
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*PublicRandRequest)(nil), "drand.PublicRandRequest")
+	proto.RegisterType((*PublicRandRangeRequest)(nil), "drand.PublicRandRangeRequest")
 	proto.RegisterType((*PublicRandResponse)(nil), "drand.PublicRandResponse")
 	proto.RegisterType((*PrivateRandRequest)(nil), "drand.PrivateRandRequest")
 	proto.RegisterType((*PrivateRandResponse)(nil), "drand.PrivateRandResponse")
@@ -373,6 +703,12 @@ func init() {
 	proto.RegisterType((*DistKeyResponse)(nil), "drand.DistKeyResponse")
 	proto.RegisterType((*HomeRequest)(nil), "drand.HomeRequest")
 	proto.RegisterType((*HomeResponse)(nil), "drand.HomeResponse")
+	proto.RegisterType((*HealthRequest)(nil), "drand.HealthRequest")
+	proto.RegisterType((*HealthResponse)(nil), "drand.HealthResponse")
+	proto.RegisterType((*ReadyRequest)(nil), "drand.ReadyRequest")
+	proto.RegisterType((*ReadyResponse)(nil), "drand.ReadyResponse")
+	proto.RegisterType((*InfoRequest)(nil), "drand.InfoRequest")
+	proto.RegisterType((*InfoResponse)(nil), "drand.InfoResponse")
 }
 
 func init() {
@@ -380,39 +716,58 @@ func init() {
 }
 
 var fileDescriptor_c0cff3fc81cf7d79 = []byte{
-	// 505 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x54, 0xc1, 0x6e, 0xd3, 0x40,
-	0x10, 0x95, 0x5b, 0x27, 0xa1, 0x13, 0x43, 0x92, 0x31, 0x2d, 0xae, 0x15, 0xa1, 0xca, 0x20, 0x54,
-	0x2a, 0x91, 0x40, 0xb9, 0x20, 0x24, 0x2e, 0x08, 0x09, 0x10, 0x97, 0xc8, 0xb9, 0x45, 0x42, 0x68,
-	0x1b, 0x2f, 0x91, 0x95, 0x7a, 0xd7, 0xec, 0xae, 0x23, 0x45, 0x88, 0x0b, 0x7c, 0x02, 0x07, 0x3e,
-	0x86, 0xcf, 0xe0, 0x17, 0xf8, 0x10, 0xe4, 0xdd, 0x75, 0xec, 0x90, 0x9c, 0x7a, 0xdb, 0x79, 0xf3,
-	0xe6, 0xcd, 0xdb, 0x9d, 0xb1, 0xa1, 0x97, 0x08, 0xc2, 0x92, 0x31, 0xc9, 0xd3, 0x51, 0x2e, 0xb8,
-	0xe2, 0xd8, 0xd2, 0x40, 0x38, 0x5c, 0x70, 0xbe, 0xb8, 0xa6, 0x65, 0x62, 0x4c, 0x18, 0xe3, 0x8a,
-	0xa8, 0x94, 0x33, 0x69, 0x48, 0x21, 0x9a, 0xaa, 0x39, 0xcf, 0x32, 0xce, 0x0c, 0x16, 0x3d, 0x86,
-	0xc1, 0xa4, 0xb8, 0xba, 0x4e, 0xe7, 0x31, 0x61, 0x49, 0x4c, 0xbf, 0x14, 0x54, 0x2a, 0xbc, 0x0b,
-	0x2d, 0xc1, 0x0b, 0x96, 0x04, 0xce, 0x99, 0x73, 0xee, 0xc6, 0x26, 0x88, 0x7e, 0x39, 0x80, 0x4d,
-	0xae, 0xcc, 0x39, 0x93, 0x74, 0x3f, 0x19, 0x87, 0x70, 0x24, 0xd3, 0x05, 0x23, 0xaa, 0x10, 0x34,
-	0x38, 0x38, 0x73, 0xce, 0xbd, 0xb8, 0x06, 0xf0, 0x09, 0x60, 0x2e, 0xe8, 0x2a, 0xe5, 0x85, 0xfc,
-	0x54, 0xd3, 0x0e, 0x35, 0x6d, 0x50, 0x65, 0xa6, 0x1b, 0xfa, 0x7d, 0x80, 0xd2, 0x39, 0xcf, 0x18,
-	0x95, 0x32, 0x70, 0x35, 0xad, 0x81, 0x44, 0x23, 0xc0, 0x89, 0x48, 0x57, 0x44, 0xd1, 0xe6, 0x2d,
-	0x02, 0xe8, 0x08, 0x73, 0xd4, 0xd6, 0xbc, 0xb8, 0x0a, 0xa3, 0x67, 0xe0, 0x6f, 0xf1, 0xed, 0x4d,
-	0x42, 0xb8, 0x25, 0xec, 0xd9, 0x56, 0x6c, 0xe2, 0xa8, 0x0f, 0x77, 0xde, 0xa4, 0x52, 0x7d, 0xa0,
-	0x6b, 0x2b, 0x1f, 0x3d, 0x80, 0xde, 0x06, 0xb1, 0x02, 0x7d, 0x38, 0x5c, 0xd2, 0xb5, 0xbd, 0x6e,
-	0x79, 0x8c, 0x6e, 0x43, 0xf7, 0x1d, 0xcf, 0x68, 0x55, 0xf3, 0x08, 0x3c, 0x13, 0xda, 0x82, 0x13,
-	0x68, 0x4b, 0x45, 0x54, 0x21, 0x75, 0xbf, 0xa3, 0xd8, 0x46, 0x97, 0xbf, 0x5d, 0x68, 0x9b, 0xa7,
-	0xc6, 0x0c, 0xa0, 0x7e, 0x74, 0x0c, 0x46, 0x7a, 0x86, 0xa3, 0x9d, 0x99, 0x85, 0xa7, 0x7b, 0x32,
-	0xd6, 0xfb, 0xc5, 0xf7, 0x3f, 0x7f, 0x7f, 0x1e, 0x3c, 0xc4, 0xae, 0xde, 0x8b, 0x5c, 0x13, 0x66,
-	0xc7, 0xe8, 0x37, 0xc2, 0xf1, 0x57, 0x3d, 0xb6, 0x6f, 0xf8, 0xc3, 0x81, 0x7e, 0x2d, 0x31, 0x55,
-	0x82, 0x92, 0xec, 0x66, 0x5d, 0x5f, 0xe8, 0xae, 0x97, 0x88, 0xcd, 0x36, 0x52, 0x0b, 0xce, 0x86,
-	0x18, 0xee, 0xa2, 0x95, 0x87, 0xa7, 0x0e, 0x7e, 0x84, 0x6e, 0x63, 0x40, 0xb8, 0xe9, 0xb2, 0x33,
-	0xe4, 0x30, 0xdc, 0x97, 0xb2, 0x0e, 0xee, 0x69, 0x07, 0x83, 0xc8, 0x33, 0xbd, 0x0c, 0xe3, 0xa5,
-	0x73, 0x81, 0xef, 0xa1, 0xf5, 0x56, 0xf0, 0x22, 0x47, 0xdf, 0x56, 0xeb, 0xa8, 0x92, 0xc4, 0x26,
-	0x38, 0x21, 0xf3, 0x25, 0x55, 0x95, 0x14, 0xf6, 0xb4, 0x54, 0xca, 0x3e, 0xf3, 0xf1, 0x42, 0x2b,
-	0x4c, 0xa1, 0x63, 0xb7, 0x00, 0x8f, 0x6d, 0xdd, 0xf6, 0x9e, 0x84, 0x27, 0xff, 0xc3, 0xd6, 0xdd,
-	0xa9, 0x96, 0xf4, 0x71, 0x50, 0x4b, 0x26, 0xa9, 0x54, 0x4b, 0xba, 0xc6, 0x57, 0xe0, 0x96, 0x6b,
-	0x82, 0x95, 0x93, 0xc6, 0x0a, 0x85, 0xfe, 0x16, 0x66, 0xb5, 0x3c, 0xad, 0xd5, 0x46, 0xb7, 0xd4,
-	0x7a, 0xdd, 0x99, 0x99, 0xdf, 0xc1, 0x55, 0x5b, 0x7f, 0xe3, 0xcf, 0xff, 0x05, 0x00, 0x00, 0xff,
-	0xff, 0x57, 0x74, 0x24, 0xec, 0x2f, 0x04, 0x00, 0x00,
+	// 805 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x95, 0xcd, 0x6e, 0x23, 0x45,
+	0x10, 0xc7, 0x35, 0x89, 0x3f, 0x70, 0x79, 0x1c, 0xc7, 0x35, 0x49, 0xd6, 0x3b, 0xca, 0xa2, 0x65,
+	0x90, 0x20, 0x42, 0x22, 0x5e, 0x96, 0x0b, 0x42, 0x20, 0xa1, 0x08, 0x2d, 0x59, 0xf6, 0x12, 0x4d,
+	0x38, 0x45, 0x42, 0x56, 0x6f, 0xa6, 0x63, 0x8f, 0xe2, 0xe9, 0x36, 0xd3, 0x3d, 0x2b, 0x59, 0xab,
+	0xbd, 0xc0, 0x89, 0x33, 0x37, 0x9e, 0x85, 0x07, 0xe0, 0xce, 0x2b, 0xf0, 0x20, 0xa8, 0xab, 0x7b,
+	0xc6, 0xed, 0x38, 0x10, 0x89, 0x5b, 0xd7, 0xbf, 0xab, 0x7e, 0x5d, 0x53, 0xae, 0x2a, 0xc3, 0x30,
+	0x2b, 0x99, 0xc8, 0x26, 0x6c, 0x99, 0x9f, 0x2e, 0x4b, 0xa9, 0x25, 0xb6, 0x49, 0x88, 0x8f, 0x67,
+	0x52, 0xce, 0x16, 0xdc, 0x5c, 0x4c, 0x98, 0x10, 0x52, 0x33, 0x9d, 0x4b, 0xa1, 0xac, 0x53, 0x8c,
+	0x36, 0xea, 0x5a, 0x16, 0x85, 0x14, 0x56, 0x4b, 0xce, 0x60, 0x74, 0x51, 0xbd, 0x5e, 0xe4, 0xd7,
+	0x29, 0x13, 0x59, 0xca, 0x7f, 0xaa, 0xb8, 0xd2, 0x78, 0x00, 0xed, 0x52, 0x56, 0x22, 0x1b, 0x07,
+	0x4f, 0x83, 0x93, 0x56, 0x6a, 0x0d, 0x7c, 0x04, 0x5d, 0xa6, 0xa7, 0x3a, 0x2f, 0xf8, 0x78, 0xe7,
+	0x69, 0x70, 0xb2, 0x9b, 0x76, 0x98, 0xfe, 0x21, 0x2f, 0x78, 0xf2, 0x15, 0x1c, 0x79, 0x0c, 0x26,
+	0x66, 0xbc, 0x06, 0x21, 0xb4, 0x6e, 0x4a, 0x59, 0x38, 0x0e, 0x9d, 0x71, 0x0f, 0x76, 0xb4, 0x24,
+	0x42, 0x2b, 0xdd, 0xd1, 0x32, 0xf9, 0x33, 0x00, 0xf4, 0x53, 0x50, 0x4b, 0x29, 0x14, 0xff, 0x97,
+	0x1c, 0x8e, 0xa1, 0xa7, 0xf2, 0x99, 0x60, 0xba, 0x2a, 0x6d, 0x16, 0x61, 0xba, 0x16, 0xf0, 0x53,
+	0xc0, 0x65, 0xc9, 0xdf, 0xe4, 0xb2, 0x52, 0xd3, 0xb5, 0xdb, 0x2e, 0xb9, 0x8d, 0xea, 0x9b, 0xcb,
+	0xc6, 0xfd, 0x7d, 0x00, 0x53, 0x10, 0x59, 0x08, 0xae, 0xd4, 0xb8, 0x45, 0x6e, 0x9e, 0x82, 0x13,
+	0x88, 0x2a, 0x71, 0x3d, 0x67, 0xb9, 0xe0, 0x99, 0xc7, 0x6b, 0x93, 0x23, 0x36, 0x57, 0x0d, 0x30,
+	0x39, 0x05, 0xbc, 0x28, 0xf3, 0x37, 0x4c, 0x73, 0xbf, 0x9a, 0x63, 0xe8, 0x96, 0xf6, 0x48, 0xdf,
+	0x12, 0xa6, 0xb5, 0x99, 0x7c, 0x06, 0xd1, 0x86, 0xbf, 0xfb, 0xf4, 0x18, 0xde, 0x2b, 0xdd, 0xd9,
+	0x45, 0x34, 0x76, 0xb2, 0x0f, 0x7b, 0xdf, 0xe6, 0x4a, 0xbf, 0xe2, 0x2b, 0x87, 0x4f, 0x3e, 0x84,
+	0x61, 0xa3, 0x38, 0xc0, 0x3e, 0xec, 0xde, 0xf2, 0x95, 0xab, 0x8f, 0x39, 0x26, 0x03, 0xe8, 0x9f,
+	0xcb, 0xa2, 0xfe, 0x5d, 0x92, 0x8f, 0x20, 0xb4, 0xa6, 0x0b, 0x38, 0x82, 0x8e, 0xd2, 0x4c, 0x57,
+	0x8a, 0xde, 0xeb, 0xa5, 0xce, 0x4a, 0x86, 0x30, 0x38, 0xe7, 0x6c, 0xa1, 0xe7, 0x75, 0xe0, 0x09,
+	0xec, 0xd5, 0xc2, 0x03, 0xa1, 0x7b, 0x10, 0xa6, 0x9c, 0x65, 0x4d, 0x9a, 0x1f, 0xc3, 0xc0, 0xd9,
+	0x0f, 0x04, 0x0e, 0xa0, 0xff, 0x52, 0xdc, 0xc8, 0x3a, 0xee, 0x8f, 0x00, 0x42, 0x6b, 0xbb, 0xb8,
+	0x27, 0x00, 0xb3, 0x52, 0x56, 0xcb, 0xe9, 0x9c, 0xa9, 0xb9, 0xab, 0x4f, 0x8f, 0x94, 0x73, 0xa6,
+	0xe6, 0xf8, 0x01, 0x84, 0x33, 0x2e, 0xb8, 0xca, 0x95, 0xdf, 0xaa, 0x7d, 0xa7, 0x99, 0x7e, 0x35,
+	0x2f, 0x2f, 0x79, 0x99, 0xcb, 0x8c, 0x5a, 0x63, 0x90, 0x3a, 0xcb, 0x90, 0x97, 0xd4, 0x88, 0x53,
+	0x53, 0x3d, 0xdb, 0x0f, 0x3d, 0xab, 0xbc, 0xe2, 0x2b, 0x43, 0x5e, 0x30, 0xcd, 0x95, 0x9e, 0xda,
+	0xc6, 0x6c, 0x53, 0x63, 0xf6, 0xad, 0x96, 0x1a, 0xc9, 0x14, 0x7e, 0xc1, 0x66, 0xe3, 0x0e, 0xdd,
+	0x98, 0xe3, 0xf3, 0xdf, 0xbb, 0xd0, 0xb1, 0xdd, 0x8d, 0xbf, 0x06, 0x00, 0xeb, 0x46, 0xc7, 0xf1,
+	0x29, 0x8d, 0xe3, 0xe9, 0xd6, 0xf8, 0xc5, 0x8f, 0xef, 0xb9, 0x71, 0x3f, 0xff, 0x8b, 0x9f, 0xff,
+	0xfa, 0xfb, 0xb7, 0x9d, 0x6f, 0xb0, 0x4f, 0x23, 0x6e, 0x73, 0xbb, 0x3a, 0xc4, 0xc8, 0x33, 0x27,
+	0x6f, 0x29, 0xbd, 0x77, 0x57, 0x31, 0x8e, 0x7d, 0x99, 0xe9, 0xc9, 0x5b, 0x37, 0xbf, 0xef, 0xf0,
+	0x97, 0x00, 0xf6, 0xd7, 0xf8, 0x4b, 0x5d, 0x72, 0x56, 0xfc, 0xbf, 0x8c, 0xbe, 0xa0, 0x8c, 0x9e,
+	0x23, 0xfa, 0x6f, 0x29, 0x02, 0x5e, 0x1d, 0x63, 0xbc, 0xad, 0xd6, 0xf9, 0x3d, 0x0b, 0x70, 0x0e,
+	0xc3, 0x3b, 0x8b, 0x03, 0x9f, 0x6c, 0xbf, 0xe4, 0x2d, 0x94, 0xff, 0x4a, 0xe4, 0x31, 0x25, 0x12,
+	0xe1, 0xc8, 0x7f, 0xb2, 0x34, 0xc1, 0xcf, 0x02, 0xfc, 0x11, 0xfa, 0xde, 0xa4, 0x61, 0x83, 0xd9,
+	0x9a, 0xd6, 0x38, 0xbe, 0xef, 0xca, 0x3d, 0xf1, 0x88, 0x9e, 0x18, 0x25, 0xa1, 0x7d, 0xc2, 0x7a,
+	0x7c, 0x19, 0x7c, 0x82, 0x2f, 0xa1, 0xfd, 0x9d, 0xe9, 0x40, 0x8c, 0x5c, 0x34, 0x59, 0x35, 0x12,
+	0x7d, 0xf1, 0x82, 0x5d, 0xdf, 0x72, 0x5d, 0xa3, 0x70, 0x48, 0xa8, 0x5c, 0xdc, 0xc8, 0x09, 0xf5,
+	0x30, 0x5e, 0x42, 0xd7, 0x8d, 0x33, 0x1e, 0xba, 0xb8, 0xcd, 0x81, 0x8f, 0x8f, 0xee, 0xca, 0xf7,
+	0x16, 0x80, 0x90, 0x59, 0xae, 0xf4, 0x2d, 0x5f, 0xe1, 0xd7, 0xd0, 0x32, 0xf3, 0x8e, 0x75, 0x26,
+	0xde, 0x2e, 0x88, 0xa3, 0x0d, 0xcd, 0xb1, 0x42, 0x62, 0x75, 0xb0, 0x65, 0x58, 0xf8, 0x3d, 0x74,
+	0xec, 0xd4, 0xe3, 0x41, 0xed, 0xec, 0x6f, 0x85, 0xf8, 0xf0, 0x8e, 0xea, 0x20, 0x11, 0x41, 0x06,
+	0xae, 0x59, 0xe7, 0x96, 0xf0, 0x02, 0xda, 0xb4, 0x07, 0x9a, 0x52, 0xf9, 0x5b, 0x22, 0x3e, 0xd8,
+	0x14, 0x1d, 0x08, 0x09, 0x14, 0x22, 0x10, 0xa8, 0xa4, 0xf0, 0x33, 0x68, 0x99, 0xb5, 0xd0, 0x7c,
+	0x92, 0xb7, 0x33, 0xe2, 0x68, 0x43, 0x73, 0x90, 0x11, 0x41, 0xfa, 0xd8, 0x6b, 0xca, 0x73, 0xd6,
+	0xbd, 0xb2, 0xff, 0x9b, 0xaf, 0x3b, 0xf4, 0x67, 0xf8, 0xf9, 0x3f, 0x03, 0x00, 0x48, 0xf2, 0xd6,
+	0xe4, 0x58, 0x07, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -431,6 +786,10 @@ type PublicClient interface {
 	// generated by the drand network.
 	PublicRand(ctx context.Context, in *PublicRandRequest, opts ...grpc.CallOption) (*PublicRandResponse, error)
 	PublicRandStream(ctx context.Context, in *PublicRandRequest, opts ...grpc.CallOption) (Public_PublicRandStreamClient, error)
+	// PublicRandRange streams in order the beacons of a range of rounds. The
+	// number of beacons sent per request is bounded: clients should request
+	// the remaining rounds starting after the last round received.
+	PublicRandRange(ctx context.Context, in *PublicRandRangeRequest, opts ...grpc.CallOption) (Public_PublicRandRangeClient, error)
 	// PrivateRand is the method that returns the private randomness generated
 	// by the drand node only.
 	PrivateRand(ctx context.Context, in *PrivateRandRequest, opts ...grpc.CallOption) (*PrivateRandResponse, error)
@@ -441,6 +800,14 @@ type PublicClient interface {
 	DistKey(ctx context.Context, in *DistKeyRequest, opts ...grpc.CallOption) (*DistKeyResponse, error)
 	// Home is a simple endpoint
 	Home(ctx context.Context, in *HomeRequest, opts ...grpc.CallOption) (*HomeResponse, error)
+	// Health reports that the node is alive.
+	Health(ctx context.Context, in *HealthRequest, opts ...grpc.CallOption) (*HealthResponse, error)
+	// Ready reports whether the node serves the round preceding the current
+	// round or a later one. It fails with the Unavailable code otherwise.
+	Ready(ctx context.Context, in *ReadyRequest, opts ...grpc.CallOption) (*ReadyResponse, error)
+	// Info describes the chain served and how far the latest round served
+	// lags behind the current round.
+	Info(ctx context.Context, in *InfoRequest, opts ...grpc.CallOption) (*InfoResponse, error)
 }
 
 type publicClient struct {
@@ -492,6 +859,38 @@ func (x *publicPublicRandStreamClient) Recv() (*PublicRandResponse, error) {
 	return m, nil
 }
 
+func (c *publicClient) PublicRandRange(ctx context.Context, in *PublicRandRangeRequest, opts ...grpc.CallOption) (Public_PublicRandRangeClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Public_serviceDesc.Streams[1], "/drand.Public/PublicRandRange", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &publicPublicRandRangeClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Public_PublicRandRangeClient interface {
+	Recv() (*PublicRandResponse, error)
+	grpc.ClientStream
+}
+
+type publicPublicRandRangeClient struct {
+	grpc.ClientStream
+}
+
+func (x *publicPublicRandRangeClient) Recv() (*PublicRandResponse, error) {
+	m := new(PublicRandResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *publicClient) PrivateRand(ctx context.Context, in *PrivateRandRequest, opts ...grpc.CallOption) (*PrivateRandResponse, error) {
 	out := new(PrivateRandResponse)
 	err := c.cc.Invoke(ctx, "/drand.Public/PrivateRand", in, out, opts...)
@@ -528,12 +927,43 @@ func (c *publicClient) Home(ctx context.Context, in *HomeRequest, opts ...grpc.C
 	return out, nil
 }
 
+func (c *publicClient) Health(ctx context.Context, in *HealthRequest, opts ...grpc.CallOption) (*HealthResponse, error) {
+	out := new(HealthResponse)
+	err := c.cc.Invoke(ctx, "/drand.Public/Health", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *publicClient) Ready(ctx context.Context, in *ReadyRequest, opts ...grpc.CallOption) (*ReadyResponse, error) {
+	out := new(ReadyResponse)
+	err := c.cc.Invoke(ctx, "/drand.Public/Ready", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *publicClient) Info(ctx context.Context, in *InfoRequest, opts ...grpc.CallOption) (*InfoResponse, error) {
+	out := new(InfoResponse)
+	err := c.cc.Invoke(ctx, "/drand.Public/Info", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // PublicServer is the server API for Public service.
 type PublicServer interface {
 	// PublicRand is the method that returns the publicly verifiable randomness
 	// generated by the drand network.
 	PublicRand(context.Context, *PublicRandRequest) (*PublicRandResponse, error)
 	PublicRandStream(*PublicRandRequest, Public_PublicRandStreamServer) error
+	// PublicRandRange streams in order the beacons of a range of rounds. The
+	// number of beacons sent per request is bounded: clients should request
+	// the remaining rounds starting after the last round received.
+	PublicRandRange(*PublicRandRangeRequest, Public_PublicRandRangeServer) error
 	// PrivateRand is the method that returns the private randomness generated
 	// by the drand node only.
 	PrivateRand(context.Context, *PrivateRandRequest) (*PrivateRandResponse, error)
@@ -544,6 +974,14 @@ type PublicServer interface {
 	DistKey(context.Context, *DistKeyRequest) (*DistKeyResponse, error)
 	// Home is a simple endpoint
 	Home(context.Context, *HomeRequest) (*HomeResponse, error)
+	// Health reports that the node is alive.
+	Health(context.Context, *HealthRequest) (*HealthResponse, error)
+	// Ready reports whether the node serves the round preceding the current
+	// round or a later one. It fails with the Unavailable code otherwise.
+	Ready(context.Context, *ReadyRequest) (*ReadyResponse, error)
+	// Info describes the chain served and how far the latest round served
+	// lags behind the current round.
+	Info(context.Context, *InfoRequest) (*InfoResponse, error)
 }
 
 // UnimplementedPublicServer can be embedded to have forward compatible implementations.
@@ -556,6 +994,9 @@ func (*UnimplementedPublicServer) PublicRand(ctx context.Context, req *PublicRan
 func (*UnimplementedPublicServer) PublicRandStream(req *PublicRandRequest, srv Public_PublicRandStreamServer) error {
 	return status.Errorf(codes.Unimplemented, "method PublicRandStream not implemented")
 }
+func (*UnimplementedPublicServer) PublicRandRange(req *PublicRandRangeRequest, srv Public_PublicRandRangeServer) error {
+	return status.Errorf(codes.Unimplemented, "method PublicRandRange not implemented")
+}
 func (*UnimplementedPublicServer) PrivateRand(ctx context.Context, req *PrivateRandRequest) (*PrivateRandResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method PrivateRand not implemented")
 }
@@ -568,6 +1009,15 @@ func (*UnimplementedPublicServer) DistKey(ctx context.Context, req *DistKeyReque
 func (*UnimplementedPublicServer) Home(ctx context.Context, req *HomeRequest) (*HomeResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Home not implemented")
 }
+func (*UnimplementedPublicServer) Health(ctx context.Context, req *HealthRequest) (*HealthResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Health not implemented")
+}
+func (*UnimplementedPublicServer) Ready(ctx context.Context, req *ReadyRequest) (*ReadyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Ready not implemented")
+}
+func (*UnimplementedPublicServer) Info(ctx context.Context, req *InfoRequest) (*InfoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Info not implemented")
+}
 
 func RegisterPublicServer(s *grpc.Server, srv PublicServer) {
 	s.RegisterService(&_Public_serviceDesc, srv)
@@ -612,6 +1062,27 @@ func (x *publicPublicRandStreamServer) Send(m *PublicRandResponse) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _Public_PublicRandRange_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(PublicRandRangeRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PublicServer).PublicRandRange(m, &publicPublicRandRangeServer{stream})
+}
+
+type Public_PublicRandRangeServer interface {
+	Send(*PublicRandResponse) error
+	grpc.ServerStream
+}
+
+type publicPublicRandRangeServer struct {
+	grpc.ServerStream
+}
+
+func (x *publicPublicRandRangeServer) Send(m *PublicRandResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _Public_PrivateRand_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(PrivateRandRequest)
 	if err := dec(in); err != nil {
@@ -684,6 +1155,60 @@ func _Public_Home_Handler(srv interface{}, ctx context.Context, dec func(interfa
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Public_Health_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HealthRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PublicServer).Health(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/drand.Public/Health",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PublicServer).Health(ctx, req.(*HealthRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Public_Ready_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReadyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PublicServer).Ready(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/drand.Public/Ready",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PublicServer).Ready(ctx, req.(*ReadyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Public_Info_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PublicServer).Info(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/drand.Public/Info",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PublicServer).Info(ctx, req.(*InfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Public_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "drand.Public",
 	HandlerType: (*PublicServer)(nil),
@@ -708,6 +1233,18 @@ var _Public_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Home",
 			Handler:    _Public_Home_Handler,
 		},
+		{
+			MethodName: "Health",
+			Handler:    _Public_Health_Handler,
+		},
+		{
+			MethodName: "Ready",
+			Handler:    _Public_Ready_Handler,
+		},
+		{
+			MethodName: "Info",
+			Handler:    _Public_Info_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -715,6 +1252,11 @@ var _Public_serviceDesc = grpc.ServiceDesc{
 			Handler:       _Public_PublicRandStream_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "PublicRandRange",
+			Handler:       _Public_PublicRandRange_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "drand/api.proto",
 }
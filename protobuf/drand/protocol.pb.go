@@ -171,7 +171,10 @@ type PartialBeaconPacket struct {
 	PreviousSig []byte `protobuf:"bytes,2,opt,name=previous_sig,json=previousSig,proto3" json:"previous_sig,omitempty"`
 	// partial signature - a threshold of them needs to be aggregated to produce
 	// the final beacon at the given round.
-	PartialSig           []byte   `protobuf:"bytes,3,opt,name=partial_sig,json=partialSig,proto3" json:"partial_sig,omitempty"`
+	PartialSig []byte `protobuf:"bytes,3,opt,name=partial_sig,json=partialSig,proto3" json:"partial_sig,omitempty"`
+	// partial signature over the unchained message of the round, which only
+	// depends on the round, aggregated like partial_sig.
+	PartialUnchainedSig  []byte   `protobuf:"bytes,4,opt,name=partial_unchained_sig,json=partialUnchainedSig,proto3" json:"partial_unchained_sig,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -223,6 +226,13 @@ func (m *PartialBeaconPacket) GetPartialSig() []byte {
 	return nil
 }
 
+func (m *PartialBeaconPacket) GetPartialUnchainedSig() []byte {
+	if m != nil {
+		return m.PartialUnchainedSig
+	}
+	return nil
+}
+
 type DKGPacket struct {
 	Dkg                  *dkg.Packet `protobuf:"bytes,1,opt,name=dkg,proto3" json:"dkg,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
@@ -355,9 +365,11 @@ func (m *SyncRequest) GetFromRound() uint64 {
 }
 
 type BeaconPacket struct {
-	PreviousSig          []byte   `protobuf:"bytes,1,opt,name=previous_sig,json=previousSig,proto3" json:"previous_sig,omitempty"`
-	Round                uint64   `protobuf:"varint,2,opt,name=round,proto3" json:"round,omitempty"`
-	Signature            []byte   `protobuf:"bytes,3,opt,name=signature,proto3" json:"signature,omitempty"`
+	PreviousSig []byte `protobuf:"bytes,1,opt,name=previous_sig,json=previousSig,proto3" json:"previous_sig,omitempty"`
+	Round       uint64 `protobuf:"varint,2,opt,name=round,proto3" json:"round,omitempty"`
+	Signature   []byte `protobuf:"bytes,3,opt,name=signature,proto3" json:"signature,omitempty"`
+	// signature over the unchained message of the round, if produced.
+	UnchainedSig         []byte   `protobuf:"bytes,4,opt,name=unchained_sig,json=unchainedSig,proto3" json:"unchained_sig,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -409,6 +421,13 @@ func (m *BeaconPacket) GetSignature() []byte {
 	return nil
 }
 
+func (m *BeaconPacket) GetUnchainedSig() []byte {
+	if m != nil {
+		return m.UnchainedSig
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*SignalDKGPacket)(nil), "drand.SignalDKGPacket")
 	proto.RegisterType((*DKGInfoPacket)(nil), "drand.DKGInfoPacket")
@@ -424,43 +443,45 @@ func init() {
 }
 
 var fileDescriptor_e344a98fea1e2f3a = []byte{
-	// 565 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x94, 0xdd, 0x8e, 0xd2, 0x40,
-	0x14, 0xc7, 0x53, 0x16, 0x56, 0x7a, 0x0a, 0x59, 0x1d, 0x88, 0x69, 0x9a, 0xdd, 0x88, 0xf5, 0x86,
-	0x6c, 0x0c, 0xac, 0x6b, 0x62, 0x62, 0xe2, 0xd5, 0x8a, 0xe2, 0x86, 0x98, 0x90, 0xe2, 0x95, 0x37,
-	0xa4, 0x76, 0x86, 0x76, 0x02, 0xcc, 0xd4, 0xe9, 0xd4, 0x95, 0x77, 0xf4, 0x31, 0x7c, 0x10, 0x33,
-	0x1f, 0x40, 0x81, 0xbd, 0xf0, 0x82, 0x84, 0xf9, 0x9d, 0x8f, 0xf6, 0x7f, 0xce, 0xbf, 0x03, 0x5d,
-	0x2c, 0x62, 0x86, 0x87, 0xb9, 0xe0, 0x92, 0x27, 0x7c, 0x35, 0xd0, 0x7f, 0x50, 0x43, 0xd3, 0xa0,
-	0x9b, 0x88, 0x4d, 0x2e, 0xf9, 0x10, 0x2f, 0x53, 0xf5, 0x33, 0xc1, 0x00, 0x99, 0x92, 0x84, 0xaf,
-	0xd7, 0x9c, 0x19, 0x16, 0xfe, 0x75, 0xe0, 0x62, 0x46, 0x53, 0x16, 0xaf, 0x46, 0x93, 0xf1, 0x34,
-	0x4e, 0x96, 0x44, 0xa2, 0x57, 0x50, 0x67, 0x1c, 0x13, 0xdf, 0xe9, 0x39, 0x7d, 0xef, 0xf6, 0x62,
-	0xa0, 0xcb, 0x06, 0xf7, 0x98, 0x30, 0x49, 0xe5, 0x26, 0xd2, 0x41, 0x14, 0x40, 0x93, 0xfc, 0xce,
-	0x49, 0x22, 0x09, 0xf6, 0x6b, 0x3d, 0xa7, 0xdf, 0x8e, 0x76, 0x67, 0x74, 0x09, 0xae, 0xcc, 0x04,
-	0x29, 0x32, 0xbe, 0xc2, 0xfe, 0x99, 0x0e, 0xee, 0x01, 0x7a, 0x01, 0x1e, 0x5e, 0xa6, 0x73, 0x49,
-	0xd7, 0x84, 0x97, 0xd2, 0xaf, 0xf7, 0x9c, 0x7e, 0x3d, 0x02, 0xbc, 0x4c, 0xbf, 0x19, 0x82, 0x5e,
-	0x42, 0xab, 0x20, 0x89, 0x20, 0x72, 0x9e, 0x0b, 0xce, 0x17, 0x7e, 0xa3, 0xe7, 0xf4, 0xdd, 0xc8,
-	0x33, 0x6c, 0xaa, 0x10, 0x1a, 0x40, 0x27, 0x17, 0xe4, 0x17, 0xe5, 0x65, 0x31, 0x4f, 0x05, 0x2f,
-	0xf3, 0x79, 0x16, 0x17, 0x99, 0x7f, 0xde, 0x73, 0xfa, 0xad, 0xe8, 0xd9, 0x36, 0x34, 0x56, 0x91,
-	0x2f, 0x71, 0x91, 0x85, 0x09, 0xb4, 0x47, 0x93, 0xf1, 0x3d, 0x5b, 0x70, 0xab, 0x71, 0x08, 0x2e,
-	0x23, 0x0f, 0xa6, 0xd6, 0x0a, 0x45, 0x56, 0xa8, 0xae, 0x32, 0x69, 0x51, 0x93, 0x91, 0x07, 0x7d,
-	0x3e, 0x79, 0xa9, 0xda, 0xc9, 0x4b, 0x85, 0x1c, 0x3a, 0xd3, 0x58, 0x48, 0x1a, 0xaf, 0xee, 0x48,
-	0x9c, 0x70, 0x66, 0x1f, 0xd5, 0x85, 0x86, 0xe0, 0x25, 0xc3, 0xfa, 0x31, 0xf5, 0xc8, 0x1c, 0x54,
-	0xbf, 0x9d, 0x82, 0x82, 0xa6, 0xba, 0x5f, 0x2b, 0xf2, 0xb6, 0x6c, 0x46, 0x53, 0x35, 0xa8, 0xdc,
-	0xf4, 0xd3, 0x19, 0x67, 0x3a, 0x03, 0x2c, 0x9a, 0xd1, 0x34, 0xbc, 0x06, 0x77, 0xbf, 0xb5, 0x2b,
-	0x38, 0xc3, 0xcb, 0xd4, 0x6a, 0xf1, 0x06, 0x6a, 0xed, 0x56, 0x84, 0xe2, 0xe1, 0x57, 0x68, 0x47,
-	0xa4, 0xc8, 0x62, 0x41, 0xfe, 0x2b, 0x1f, 0x5d, 0x01, 0x54, 0x06, 0x6b, 0xd4, 0xba, 0xe9, 0x6e,
-	0xa0, 0xaf, 0xc1, 0x9b, 0x6d, 0x58, 0x12, 0x91, 0x9f, 0x25, 0x29, 0x54, 0x33, 0x58, 0x08, 0xbe,
-	0x9e, 0x57, 0x85, 0xba, 0x8a, 0x44, 0x0a, 0x84, 0x04, 0x5a, 0x07, 0x23, 0x39, 0x16, 0xef, 0x9c,
-	0x8a, 0xdf, 0x4d, 0xad, 0x56, 0x9d, 0xda, 0x25, 0xb8, 0x85, 0x72, 0xab, 0x2c, 0x05, 0xb1, 0x03,
-	0xd9, 0x83, 0xdb, 0x3f, 0x35, 0x68, 0x4e, 0xed, 0x07, 0x81, 0x3e, 0x40, 0xb7, 0x62, 0x6c, 0x21,
-	0x69, 0x42, 0xf3, 0x98, 0x49, 0xf4, 0xdc, 0xae, 0xf9, 0xc8, 0xf5, 0x41, 0xcb, 0xf2, 0x4f, 0xeb,
-	0x5c, 0x6e, 0xd0, 0x1b, 0xf0, 0xa6, 0x65, 0x91, 0x59, 0xd3, 0xa0, 0xae, 0x0d, 0x1e, 0x98, 0xe8,
-	0xa8, 0xe4, 0x1a, 0x9a, 0x9f, 0x95, 0xc7, 0x47, 0x93, 0x31, 0x7a, 0xba, 0xcf, 0x7f, 0x34, 0xf7,
-	0x06, 0xc0, 0x6e, 0x43, 0x65, 0x6f, 0xbb, 0x1f, 0x2c, 0xe8, 0xa8, 0xe2, 0x3d, 0xb4, 0x0f, 0xcc,
-	0x85, 0x02, 0x1b, 0x7e, 0xc4, 0x72, 0x47, 0xa5, 0xef, 0xc0, 0x55, 0xbb, 0xfa, 0x98, 0xc5, 0x94,
-	0xa1, 0xad, 0xcb, 0x2b, 0xdb, 0x0b, 0x3a, 0x96, 0x55, 0x7b, 0xdc, 0x38, 0x77, 0x4f, 0xbe, 0x9b,
-	0xeb, 0xe4, 0xc7, 0xb9, 0xbe, 0x2b, 0xde, 0xfe, 0x0b, 0x00, 0x00, 0xff, 0xff, 0xdf, 0xa9, 0x5a,
-	0x5c, 0x74, 0x04, 0x00, 0x00,
+	// 602 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x54, 0xdf, 0x8e, 0xd2, 0x4e,
+	0x14, 0x4e, 0x59, 0xd8, 0x1f, 0x3d, 0x2d, 0xd9, 0x9f, 0x03, 0x9a, 0xa6, 0xd9, 0x8d, 0xd8, 0xbd,
+	0x21, 0x1b, 0x03, 0x2b, 0x26, 0x26, 0x26, 0x5e, 0xad, 0x28, 0x6e, 0x88, 0x09, 0x29, 0x7a, 0xe3,
+	0x0d, 0xa9, 0x9d, 0xa1, 0x6d, 0x80, 0x99, 0x3a, 0x9d, 0xba, 0xf2, 0x0a, 0xbe, 0x86, 0xaf, 0xe3,
+	0x63, 0xf8, 0x20, 0x66, 0xa6, 0x53, 0x28, 0x7f, 0x2e, 0xbc, 0x20, 0x61, 0xbe, 0xef, 0x3b, 0xa7,
+	0x73, 0xbe, 0x73, 0xce, 0x40, 0x07, 0xf3, 0x80, 0xe2, 0x41, 0xca, 0x99, 0x60, 0x21, 0x5b, 0xf5,
+	0xd5, 0x1f, 0xd4, 0x50, 0xa8, 0xdb, 0x09, 0xf9, 0x26, 0x15, 0x6c, 0x80, 0x97, 0x91, 0xfc, 0x15,
+	0xa4, 0x8b, 0x8a, 0x90, 0x90, 0xad, 0xd7, 0x8c, 0x16, 0x98, 0xf7, 0xc7, 0x80, 0x8b, 0x59, 0x12,
+	0xd1, 0x60, 0x35, 0x9a, 0x8c, 0xa7, 0x41, 0xb8, 0x24, 0x02, 0x5d, 0x43, 0x9d, 0x32, 0x4c, 0x1c,
+	0xa3, 0x6b, 0xf4, 0xac, 0xe1, 0x45, 0x5f, 0x85, 0xf5, 0xef, 0x31, 0xa1, 0x22, 0x11, 0x1b, 0x5f,
+	0x91, 0xc8, 0x85, 0x26, 0xf9, 0x91, 0x92, 0x50, 0x10, 0xec, 0xd4, 0xba, 0x46, 0xaf, 0xe5, 0x6f,
+	0xcf, 0xe8, 0x12, 0x4c, 0x11, 0x73, 0x92, 0xc5, 0x6c, 0x85, 0x9d, 0x33, 0x45, 0xee, 0x00, 0xf4,
+	0x14, 0x2c, 0xbc, 0x8c, 0xe6, 0x22, 0x59, 0x13, 0x96, 0x0b, 0xa7, 0xde, 0x35, 0x7a, 0x75, 0x1f,
+	0xf0, 0x32, 0xfa, 0x54, 0x20, 0xe8, 0x19, 0xd8, 0x19, 0x09, 0x39, 0x11, 0xf3, 0x94, 0x33, 0xb6,
+	0x70, 0x1a, 0x5d, 0xa3, 0x67, 0xfa, 0x56, 0x81, 0x4d, 0x25, 0x84, 0xfa, 0xd0, 0x4e, 0x39, 0xf9,
+	0x9e, 0xb0, 0x3c, 0x9b, 0x47, 0x9c, 0xe5, 0xe9, 0x3c, 0x0e, 0xb2, 0xd8, 0x39, 0xef, 0x1a, 0x3d,
+	0xdb, 0x7f, 0x54, 0x52, 0x63, 0xc9, 0x7c, 0x08, 0xb2, 0xd8, 0x0b, 0xa1, 0x35, 0x9a, 0x8c, 0xef,
+	0xe9, 0x82, 0xe9, 0x1a, 0x07, 0x60, 0x52, 0xf2, 0x50, 0xc4, 0xea, 0x42, 0x91, 0x2e, 0x54, 0x45,
+	0x15, 0x32, 0xbf, 0x49, 0xc9, 0x83, 0x3a, 0x1f, 0x5d, 0xaa, 0x76, 0x74, 0x29, 0xef, 0x97, 0x01,
+	0xed, 0x69, 0xc0, 0x45, 0x12, 0xac, 0xee, 0x48, 0x10, 0x32, 0xaa, 0xbf, 0xd5, 0x81, 0x06, 0x67,
+	0x39, 0xc5, 0xea, 0x3b, 0x75, 0xbf, 0x38, 0xc8, 0x84, 0xdb, 0x12, 0xb2, 0x24, 0x52, 0x09, 0x6d,
+	0xdf, 0x2a, 0xb1, 0x59, 0x12, 0x49, 0xa7, 0xd2, 0x22, 0x9f, 0x52, 0x9c, 0x29, 0x05, 0x68, 0x48,
+	0x0a, 0x86, 0xf0, 0xb8, 0x14, 0xe4, 0x34, 0x8c, 0x83, 0x84, 0x12, 0xac, 0xa4, 0x75, 0x25, 0x6d,
+	0x6b, 0xf2, 0x73, 0xc9, 0xcd, 0x92, 0xc8, 0xbb, 0x01, 0x73, 0xd7, 0xea, 0x2b, 0x38, 0xc3, 0xcb,
+	0x48, 0x1b, 0x60, 0xf5, 0xe5, 0xac, 0xe8, 0xca, 0x25, 0xee, 0x7d, 0x84, 0x96, 0x4f, 0xb2, 0x38,
+	0xe0, 0xe4, 0x9f, 0xf4, 0xe8, 0x0a, 0xa0, 0xd2, 0x8d, 0xc2, 0x22, 0x33, 0xda, 0x76, 0xe1, 0x39,
+	0x58, 0xb3, 0x0d, 0x0d, 0x7d, 0xf2, 0x2d, 0x27, 0x99, 0x4c, 0x06, 0x0b, 0xce, 0xd6, 0xf3, 0xaa,
+	0x39, 0xa6, 0x44, 0x7c, 0x09, 0x78, 0x3f, 0x0d, 0xb0, 0xf7, 0x7c, 0x3c, 0x74, 0xcc, 0x38, 0x76,
+	0x6c, 0x6b, 0x75, 0xad, 0x6a, 0xf5, 0x25, 0x98, 0x99, 0x9c, 0x71, 0x91, 0x73, 0xa2, 0x5d, 0xdc,
+	0x01, 0xe8, 0x1a, 0x5a, 0xa7, 0xcc, 0xb3, 0xf3, 0x8a, 0x6b, 0xc3, 0xdf, 0x35, 0x68, 0x4e, 0xf5,
+	0xae, 0xa1, 0x37, 0xd0, 0xa9, 0xec, 0x0c, 0x17, 0x49, 0x98, 0xa4, 0x01, 0x15, 0xe8, 0x89, 0x9e,
+	0xa0, 0x83, 0x85, 0x72, 0x6d, 0x8d, 0xbf, 0x5b, 0xa7, 0x62, 0x83, 0x5e, 0x80, 0x35, 0xcd, 0xb3,
+	0x58, 0xcf, 0x23, 0xea, 0x68, 0x72, 0x6f, 0x3e, 0x0f, 0x42, 0x6e, 0xa0, 0xf9, 0x5e, 0xae, 0xcf,
+	0x68, 0x32, 0x46, 0xff, 0xef, 0xf4, 0x27, 0xb5, 0xb7, 0x00, 0xba, 0x67, 0x52, 0x5d, 0x66, 0xdf,
+	0x6b, 0xe3, 0x41, 0xc4, 0x6b, 0x68, 0xed, 0x8d, 0x2d, 0x72, 0x35, 0x7d, 0x62, 0x98, 0x0f, 0x42,
+	0x5f, 0x81, 0x29, 0x3b, 0xfa, 0x56, 0x1a, 0x85, 0xca, 0x05, 0xaa, 0xf4, 0xd8, 0x6d, 0x6b, 0xac,
+	0x9a, 0xe3, 0xd6, 0xb8, 0xfb, 0xef, 0x4b, 0xf1, 0x52, 0x7d, 0x3d, 0x57, 0xcf, 0xd0, 0xcb, 0xbf,
+	0x03, 0x00, 0x9e, 0x9d, 0x86, 0x98, 0xcf, 0x04, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -14,6 +14,7 @@ import (
 	"github.com/drand/drand/key"
 	"github.com/drand/drand/net"
 	"github.com/drand/drand/protobuf/drand"
+	"github.com/drand/kyber"
 	"github.com/drand/kyber/share"
 	"github.com/drand/kyber/sign/tbls"
 	"github.com/drand/kyber/util/random"
@@ -36,6 +37,8 @@ func newMockServer(d *Data) *Server {
 
 // Group implements net.Service
 func (s *Server) Group(context.Context, *drand.GroupRequest) (*drand.GroupPacket, error) {
+	s.l.Lock()
+	defer s.l.Unlock()
 	return &drand.GroupPacket{
 		Threshold:   1,
 		Period:      60,
@@ -53,13 +56,28 @@ func (s *Server) Group(context.Context, *drand.GroupRequest) (*drand.GroupPacket
 	}, nil
 }
 
-// PublicRand implements net.Service
+// PublicRand implements net.Service. It serves the current round of the mock
+// data and moves to the next one. Other rounds requested are served signed
+// over the previous signature of the current round, or with an invalid
+// signature if BadSecondRound is set.
 func (s *Server) PublicRand(c context.Context, in *drand.PublicRandRequest) (*drand.PublicRandResponse, error) {
 	s.l.Lock()
 	defer s.l.Unlock()
 	prev := decodeHex(s.d.PreviousSignature)
+	if round := in.GetRound(); round != 0 && round != uint64(s.d.Round) {
+		signature := sign(s.d.secret, prev, int(round))
+		if s.d.BadSecondRound {
+			signature = decodeHex(s.d.Signature)
+		}
+		return &drand.PublicRandResponse{
+			Round:             round,
+			PreviousSignature: prev,
+			Signature:         signature,
+			Randomness:        sha256Hash(signature),
+		}, nil
+	}
 	signature := decodeHex(s.d.Signature)
-	if in.GetRound() == uint64(s.d.Round+1) {
+	if s.d.BadSecondRound && in.GetRound() == uint64(s.d.Round+1) {
 		signature = []byte{0x01, 0x02, 0x03}
 	}
 	randomness := sha256Hash(signature)
@@ -69,7 +87,11 @@ func (s *Server) PublicRand(c context.Context, in *drand.PublicRandRequest) (*dr
 		Signature:         signature,
 		Randomness:        randomness,
 	}
-	s.d.Round++
+	if s.d.BadSecondRound {
+		s.d.Round++
+	} else {
+		s.d = nextMockData(s.d)
+	}
 	return &resp, nil
 }
 
@@ -101,6 +123,24 @@ func (s *Server) PublicRandStream(req *drand.PublicRandRequest, stream drand.Pub
 	return <-done
 }
 
+// PublicRandRange is part of the public drand service. It only sends the
+// current round of the mock data, if it is in the requested range.
+func (s *Server) PublicRandRange(req *drand.PublicRandRangeRequest, stream drand.Public_PublicRandRangeServer) error {
+	s.l.Lock()
+	round := uint64(s.d.Round)
+	resp := &drand.PublicRandResponse{
+		Round:             round,
+		PreviousSignature: decodeHex(s.d.PreviousSignature),
+		Signature:         decodeHex(s.d.Signature),
+		Randomness:        sha256Hash(decodeHex(s.d.Signature)),
+	}
+	s.l.Unlock()
+	if req.GetFrom() > round || (req.GetTo() != 0 && req.GetTo() < round) {
+		return nil
+	}
+	return stream.Send(resp)
+}
+
 // DistKey implements net.Service
 func (s *Server) DistKey(context.Context, *drand.DistKeyRequest) (*drand.DistKeyResponse, error) {
 	fmt.Println("distkey called")
@@ -145,9 +185,13 @@ type Data struct {
 	PreviousSignature string
 	PreviousRound     int
 	Genesis           int64
+	// BadSecondRound makes the server serve the signature of the first round
+	// for all the following rounds, so only the first beacon is valid.
+	BadSecondRound bool
+	secret         kyber.Scalar
 }
 
-func generateMockData() *Data {
+func generateMockData(badSecondRound bool) *Data {
 	secret := key.KeyGroup.Scalar().Pick(random.New())
 	public := key.KeyGroup.Point().Mul(secret, nil)
 	var previous [32]byte
@@ -156,29 +200,50 @@ func generateMockData() *Data {
 	}
 	round := 1969
 	prevRound := uint64(1968)
-	msg := sha256Hash(append(previous[:], roundToBytes(round)...))
-	sshare := share.PriShare{I: 0, V: secret}
-	tsig, err := key.Scheme.Sign(&sshare, msg)
-	if err != nil {
-		panic(err)
-	}
-	tshare := tbls.SigShare(tsig)
-	sig := tshare.Value()
 	publicBuff, _ := public.MarshalBinary()
 	d := &Data{
 		Public:            publicBuff,
-		Signature:         hex.EncodeToString(sig),
+		Signature:         hex.EncodeToString(sign(secret, previous[:], round)),
 		PreviousSignature: hex.EncodeToString(previous[:]),
 		PreviousRound:     int(prevRound),
 		Round:             round,
 		Genesis:           time.Now().Unix(),
+		BadSecondRound:    badSecondRound,
+		secret:            secret,
 	}
 	return d
 }
 
-// NewMockGRPCPublicServer creates a listener that provides valid single-node randomness.
-func NewMockGRPCPublicServer(bind string) (net.Listener, net.Service) {
-	d := generateMockData()
+// nextMockData returns the data of the round following d, chained to it.
+func nextMockData(d *Data) *Data {
+	prev := decodeHex(d.Signature)
+	return &Data{
+		Public:            d.Public,
+		Signature:         hex.EncodeToString(sign(d.secret, prev, d.Round+1)),
+		PreviousSignature: d.Signature,
+		PreviousRound:     d.Round,
+		Round:             d.Round + 1,
+		Genesis:           d.Genesis,
+		BadSecondRound:    d.BadSecondRound,
+		secret:            d.secret,
+	}
+}
+
+func sign(secret kyber.Scalar, prev []byte, round int) []byte {
+	msg := sha256Hash(append(prev, roundToBytes(round)...))
+	sshare := share.PriShare{I: 0, V: secret}
+	tsig, err := key.Scheme.Sign(&sshare, msg)
+	if err != nil {
+		panic(err)
+	}
+	tshare := tbls.SigShare(tsig)
+	return tshare.Value()
+}
+
+// NewMockGRPCPublicServer creates a listener that provides valid single-node
+// randomness. If badSecondRound is set, only the first beacon served is valid.
+func NewMockGRPCPublicServer(bind string, badSecondRound bool) (net.Listener, net.Service) {
+	d := generateMockData(badSecondRound)
 	testValid(d)
 	server := newMockServer(d)
 	listener, err := net.NewGRPCListenerForPrivate(context.Background(), bind, server)
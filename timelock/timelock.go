@@ -0,0 +1,252 @@
+// Package timelock encrypts payloads that can only be decrypted once the
+// beacon of a given round is published.
+//
+// A beacon is a BLS signature on G2 over a message, under the distributed key
+// of the group on G1. Such a signature is the private key of the message seen
+// as an identity in the Boneh-Franklin identity based encryption scheme, so
+// anyone can encrypt to the message of a future round with the public key of
+// the group and decrypt with the signature once the round is produced.
+//
+// Encryption requires the message of the round to be known in advance, which
+// is not the case for the chained signature of the beacons, whose message
+// depends on the signature of the previous round. Payloads are thus encrypted
+// to the unchained message of the round, RoundMessage, and are decrypted with
+// the unchained signature of the beacon, beacon.Beacon.UnchainedSig.
+//
+// The payload is encrypted with AES-256-GCM under a random key, and the key is
+// encrypted with the FullIdent variant of Boneh-Franklin:
+//
+//	Q     = H_G2(RoundMessage(round))
+//	sigma = random 32 bytes, key = random 32 bytes
+//	r     = H3(sigma || key) as a scalar
+//	U     = r * G1
+//	V     = sigma XOR H2(e(r * P, Q))
+//	W     = key XOR H4(sigma)
+//
+// where P is the public key of the group, H_G2 is the hash to G2 of the BLS
+// signatures and H_i(x) = SHA256(i || x) with i as one byte. The ciphertext is
+// encoded as follows, with integers in big endian:
+//
+//	version (1 byte, 1) | round (8 bytes) | U (48 bytes) | V (32 bytes) |
+//	W (32 bytes) | sealed payload
+//
+// The payload is sealed with a zero nonce, since the key is never reused,
+// and the preceding fields of the ciphertext as additional data.
+package timelock
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/drand/drand/beacon"
+	"github.com/drand/drand/key"
+	"github.com/drand/kyber"
+)
+
+// Version is the version of the ciphertext format.
+const Version = 1
+
+// keySize is the size of sigma and of the symmetric key.
+const keySize = 32
+
+// headerSize is the size of the ciphertext before the sealed payload.
+var headerSize = 1 + 8 + key.KeyGroup.PointLen() + 2*keySize
+
+// ErrInvalidSignature is returned when decrypting with a signature that is not
+// the beacon of the round of the ciphertext.
+var ErrInvalidSignature = errors.New("timelock: signature does not decrypt the ciphertext")
+
+type hashablePoint interface {
+	Hash([]byte) kyber.Point
+}
+
+// RoundMessage returns the unchained message of the round, the SHA256 hash of
+// the round in big endian, signed by the unchained signature of the beacons.
+func RoundMessage(round uint64) []byte {
+	return beacon.UnchainedMessage(round)
+}
+
+// Ciphertext is a payload encrypted to a round.
+type Ciphertext struct {
+	Round   uint64
+	U       kyber.Point
+	V       []byte
+	W       []byte
+	Payload []byte
+}
+
+// Encrypt encrypts the payload so that it can be decrypted with the beacon of
+// the round of the group whose public key is given.
+func Encrypt(pub kyber.Point, round uint64, payload []byte) (*Ciphertext, error) {
+	sigma := make([]byte, keySize)
+	symKey := make([]byte, keySize)
+	if _, err := rand.Read(sigma); err != nil {
+		return nil, err
+	}
+	if _, err := rand.Read(symKey); err != nil {
+		return nil, err
+	}
+	q, err := roundPoint(round)
+	if err != nil {
+		return nil, err
+	}
+	r := h3(sigma, symKey)
+	gid := key.Pairing.Pair(key.KeyGroup.Point().Mul(r, pub), q)
+	h, err := h2(gid)
+	if err != nil {
+		return nil, err
+	}
+	c := &Ciphertext{
+		Round: round,
+		U:     key.KeyGroup.Point().Mul(r, nil),
+		V:     xor(sigma, h),
+		W:     xor(symKey, h4(sigma)),
+	}
+	header, err := c.header()
+	if err != nil {
+		return nil, err
+	}
+	aead, err := newAEAD(symKey)
+	if err != nil {
+		return nil, err
+	}
+	c.Payload = aead.Seal(nil, make([]byte, aead.NonceSize()), payload, header)
+	return c, nil
+}
+
+// Decrypt decrypts the ciphertext with the unchained signature of the beacon
+// of its round. It returns ErrInvalidSignature if the signature is not the one
+// of this round.
+func Decrypt(signature []byte, c *Ciphertext) ([]byte, error) {
+	sig := key.SigGroup.Point()
+	if err := sig.UnmarshalBinary(signature); err != nil {
+		return nil, fmt.Errorf("timelock: invalid signature: %s", err)
+	}
+	h, err := h2(key.Pairing.Pair(c.U, sig))
+	if err != nil {
+		return nil, err
+	}
+	sigma := xor(c.V, h)
+	symKey := xor(c.W, h4(sigma))
+	if !key.KeyGroup.Point().Mul(h3(sigma, symKey), nil).Equal(c.U) {
+		return nil, ErrInvalidSignature
+	}
+	header, err := c.header()
+	if err != nil {
+		return nil, err
+	}
+	aead, err := newAEAD(symKey)
+	if err != nil {
+		return nil, err
+	}
+	payload, err := aead.Open(nil, make([]byte, aead.NonceSize()), c.Payload, header)
+	if err != nil {
+		return nil, fmt.Errorf("timelock: invalid payload: %s", err)
+	}
+	return payload, nil
+}
+
+// MarshalBinary encodes the ciphertext.
+func (c *Ciphertext) MarshalBinary() ([]byte, error) {
+	header, err := c.header()
+	if err != nil {
+		return nil, err
+	}
+	return append(header, c.Payload...), nil
+}
+
+// UnmarshalBinary decodes the ciphertext.
+func (c *Ciphertext) UnmarshalBinary(buff []byte) error {
+	if len(buff) < headerSize {
+		return errors.New("timelock: ciphertext too short")
+	}
+	if buff[0] != Version {
+		return fmt.Errorf("timelock: unsupported version %d", buff[0])
+	}
+	c.Round = binary.BigEndian.Uint64(buff[1:9])
+	buff = buff[9:]
+	pointLen := key.KeyGroup.PointLen()
+	c.U = key.KeyGroup.Point()
+	if err := c.U.UnmarshalBinary(buff[:pointLen]); err != nil {
+		return fmt.Errorf("timelock: invalid ciphertext: %s", err)
+	}
+	buff = buff[pointLen:]
+	c.V = append([]byte{}, buff[:keySize]...)
+	c.W = append([]byte{}, buff[keySize:2*keySize]...)
+	c.Payload = append([]byte{}, buff[2*keySize:]...)
+	return nil
+}
+
+// header encodes the ciphertext without the sealed payload.
+func (c *Ciphertext) header() ([]byte, error) {
+	if len(c.V) != keySize || len(c.W) != keySize {
+		return nil, errors.New("timelock: invalid ciphertext")
+	}
+	u, err := c.U.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	var buff bytes.Buffer
+	buff.WriteByte(Version)
+	binary.Write(&buff, binary.BigEndian, c.Round)
+	buff.Write(u)
+	buff.Write(c.V)
+	buff.Write(c.W)
+	return buff.Bytes(), nil
+}
+
+// roundPoint hashes the message of the round to G2, as BLS signatures do.
+func roundPoint(round uint64) (kyber.Point, error) {
+	hashable, ok := key.SigGroup.Point().(hashablePoint)
+	if !ok {
+		return nil, errors.New("timelock: signature group does not support hashing")
+	}
+	return hashable.Hash(RoundMessage(round)), nil
+}
+
+func h2(gid kyber.Point) ([]byte, error) {
+	b, err := gid.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	return hash(2, b), nil
+}
+
+func h3(sigma, symKey []byte) kyber.Scalar {
+	return key.KeyGroup.Scalar().SetBytes(hash(3, sigma, symKey))
+}
+
+func h4(sigma []byte) []byte {
+	return hash(4, sigma)
+}
+
+func hash(domain byte, data ...[]byte) []byte {
+	h := sha256.New()
+	h.Write([]byte{domain})
+	for _, d := range data {
+		h.Write(d)
+	}
+	return h.Sum(nil)
+}
+
+func xor(a, b []byte) []byte {
+	out := make([]byte, len(a))
+	for i := range a {
+		out[i] = a[i] ^ b[i]
+	}
+	return out
+}
+
+func newAEAD(symKey []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(symKey)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,73 @@
+package timelock
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/drand/drand/key"
+	"github.com/drand/kyber/util/random"
+)
+
+func TestTimelock(t *testing.T) {
+	secret := key.KeyGroup.Scalar().Pick(random.New())
+	pub := key.KeyGroup.Point().Mul(secret, nil)
+	payload := []byte("the winner is number 42")
+
+	c, err := Encrypt(pub, 10, payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	buff, err := c.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	c2 := new(Ciphertext)
+	if err := c2.UnmarshalBinary(buff); err != nil {
+		t.Fatal(err)
+	}
+	if c2.Round != 10 {
+		t.Fatalf("expected round 10, got %d", c2.Round)
+	}
+
+	sig, err := key.AuthScheme.Sign(secret, RoundMessage(10))
+	if err != nil {
+		t.Fatal(err)
+	}
+	decrypted, err := Decrypt(sig, c2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(decrypted, payload) {
+		t.Fatalf("unexpected payload %q", decrypted)
+	}
+
+	// the beacon of another round does not decrypt
+	other, err := key.AuthScheme.Sign(secret, RoundMessage(11))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Decrypt(other, c2); err != ErrInvalidSignature {
+		t.Fatalf("expected an invalid signature, got %v", err)
+	}
+
+	// the round is authenticated along the payload
+	buff[1] ^= 1
+	if err := c2.UnmarshalBinary(buff); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Decrypt(sig, c2); err == nil {
+		t.Fatal("tampered ciphertext should not decrypt")
+	}
+}
+
+func TestUnmarshalErrors(t *testing.T) {
+	c := new(Ciphertext)
+	if err := c.UnmarshalBinary([]byte{Version, 0, 0}); err == nil {
+		t.Fatal("short ciphertext should fail")
+	}
+	buff := make([]byte, headerSize)
+	buff[0] = Version + 1
+	if err := c.UnmarshalBinary(buff); err == nil {
+		t.Fatal("unknown version should fail")
+	}
+}